@@ -0,0 +1,112 @@
+package task
+
+import (
+	"time"
+
+	"github.com/go-task/task/v3/internal/buildcache"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// buildCacheMaxAge is how long the files of the local build cache are kept
+// without being used, when pruning it
+const buildCacheMaxAge = 30 * 24 * time.Hour
+
+// setupBuildCache sets up the cache that stores the outputs of the tasks that
+// set cache to true. Outputs are stored locally and, if a build cache URL is
+// given, on the remote server too.
+func (e *Executor) setupBuildCache() {
+	var store buildcache.Store = buildcache.NewDirStore(e.buildCacheDir())
+	if e.BuildCacheURL != "" {
+		client, err := taskfile.NewHTTPClient(e.Insecure, e.CACert, e.Cert, e.CertKey)
+		if err != nil {
+			e.buildCacheErr = err
+			return
+		}
+		remote, err := buildcache.NewHTTPStore(e.BuildCacheURL, client, e.BuildCacheReadOnly, e.Insecure)
+		if err != nil {
+			e.buildCacheErr = err
+			return
+		}
+		store = buildcache.NewTieredStore(store, remote)
+	}
+	e.buildCache = buildcache.New(store)
+}
+
+// buildCacheDir returns the directory of the local build cache
+func (e *Executor) buildCacheDir() string {
+	return filepathext.SmartJoin(e.TempDir.Fingerprint, "cache")
+}
+
+// pruneBuildCache removes the files of the local build cache that weren't used
+// for [buildCacheMaxAge], when cleaning the fingerprints of the tasks that no
+// longer exist. The files aren't tied to a task, so they are kept when cleaning
+// the fingerprints of the given tasks. In dry mode, nothing is removed.
+func (e *Executor) pruneBuildCache(names []string) error {
+	if len(names) > 0 {
+		return nil
+	}
+	n, size, err := buildcache.NewDirStore(e.buildCacheDir()).Prune(time.Now().Add(-buildCacheMaxAge), e.Dry)
+	if err != nil {
+		return err
+	}
+	verb := "Removed"
+	if e.Dry {
+		verb = "Would remove"
+	}
+	e.Logger.Outf(logger.Default, "task: %s %d build cache file(s) unused for %d days (%s)\n",
+		verb, n, int(buildCacheMaxAge.Hours()/24), formatBytes(size))
+	return nil
+}
+
+// buildCacheKey returns the key that the outputs of the given compiled task
+// are stored under, or an empty string if they shouldn't be cached. Errors are
+// only logged, as the task can still run without the cache.
+func (e *Executor) buildCacheKey(t *ast.Task, call *Call) string {
+	if !t.Cache || len(t.Generates) == 0 || e.Dry {
+		return ""
+	}
+	key, err := buildcache.Key(t, e.Dir, e.buildCacheVars(t, call))
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: unable to compute cache key: %v\n", err)
+	}
+	return key
+}
+
+// buildCacheVars returns the values of the variables of the compiled task that
+// are declared in the Taskfiles or given by the call.
+func (e *Executor) buildCacheVars(t *ast.Task, call *Call) map[string]any {
+	vars := map[string]any{}
+	for _, name := range e.declaredVarNames(t, call) {
+		if v, ok := t.Vars.Get(name); ok {
+			vars[name] = v.Value
+		}
+	}
+	return vars
+}
+
+// restoreFromCache restores the outputs of the task stored under the given
+// key. It returns false if they aren't in the cache.
+func (e *Executor) restoreFromCache(t *ast.Task, key string) (bool, error) {
+	e.buildCacheOnce.Do(e.setupBuildCache)
+	if e.buildCacheErr != nil {
+		return false, e.buildCacheErr
+	}
+	return e.buildCache.Restore(t.Dir, key)
+}
+
+// saveToCache stores the outputs of the task under the given key.
+func (e *Executor) saveToCache(t *ast.Task, key string) error {
+	e.buildCacheOnce.Do(e.setupBuildCache)
+	if e.buildCacheErr != nil {
+		return e.buildCacheErr
+	}
+	files, err := fingerprint.Globs(t.Dir, t.Generates)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	return e.buildCache.Save(t.Dir, key, files)
+}
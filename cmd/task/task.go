@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,11 +14,17 @@ import (
 	"github.com/go-task/task/v3/args"
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/experiments"
+	"github.com/go-task/task/v3/internal/events"
 	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/flags"
+	"github.com/go-task/task/v3/internal/lint"
 	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/internal/lsp"
+	"github.com/go-task/task/v3/internal/report"
+	"github.com/go-task/task/v3/internal/taskgraph"
 	"github.com/go-task/task/v3/internal/version"
 	"github.com/go-task/task/v3/taskfile/ast"
+	taskrcast "github.com/go-task/task/v3/taskrc/ast"
 )
 
 func main() {
@@ -126,14 +133,64 @@ func run() error {
 		return nil
 	}
 
-	e := task.NewExecutor(
+	if flags.LSP {
+		return lsp.NewServer(os.Stdin, os.Stdout).Run()
+	}
+
+	if flags.Schema != "" {
+		schema := ast.Schema()
+		if flags.Schema == "taskrc" {
+			schema = taskrcast.Schema()
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(schema)
+	}
+
+	opts := []task.ExecutorOption{
 		flags.WithFlags(),
 		task.WithVersionCheck(true),
-	)
+	}
+	if flags.Events != "" {
+		f, err := events.OpenFile(flags.EventsFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		opts = append(opts, task.WithEventListener(events.NewJSON(f)))
+	}
+	var recorder *report.Recorder
+	if flags.SummaryReport != "" {
+		recorder = report.NewRecorder()
+		opts = append(opts, task.WithEventListener(recorder))
+	}
+	var junit *report.JUnit
+	if flags.JUnit != "" {
+		junit = report.NewJUnit()
+		opts = append(opts, task.WithEventListener(junit))
+	}
+	var trace *report.Trace
+	if flags.Trace != "" {
+		trace = report.NewTrace()
+		opts = append(opts, task.WithEventListener(trace))
+	}
+
+	e := task.NewExecutor(opts...)
+	if flags.Fmt {
+		return e.FormatTaskfile(flags.Check)
+	}
+
 	if err := e.Setup(); err != nil {
+		if flags.ValidateTaskfile {
+			return validate(log, e, err)
+		}
 		return err
 	}
 
+	if flags.ValidateTaskfile {
+		return validate(log, e, nil)
+	}
+
 	if flags.ClearCache {
 		cachePath := filepath.Join(e.TempDir.Remote, "remote")
 		return os.RemoveAll(cachePath)
@@ -167,8 +224,31 @@ func run() error {
 	}
 	calls, globals := args.Parse(cliArgsPreDash...)
 
-	// If there are no calls, run the default task instead
-	if len(calls) == 0 {
+	if flags.Graph != "" {
+		g, err := e.TaskGraph(calls...)
+		if err != nil {
+			return err
+		}
+		return taskgraph.Write(os.Stdout, g, flags.Graph)
+	}
+
+	if flags.CleanFingerprints {
+		names := make([]string, 0, len(calls))
+		for _, call := range calls {
+			names = append(names, call.Task)
+		}
+		return e.CleanFingerprints(names...)
+	}
+
+	// With no calls, the user picks the task to run if asked to, or if prompts
+	// are enabled and there is no default task. Otherwise, the default task
+	// runs.
+	_, hasDefault := e.Taskfile.Tasks.Get("default")
+	pick := flags.Pick || (len(calls) == 0 && flags.Interactive && !hasDefault)
+	if pick && len(calls) > 0 {
+		return errors.New("task: --pick can't be used with task names")
+	}
+	if len(calls) == 0 && !pick {
 		calls = append(calls, &task.Call{Task: "default"})
 	}
 
@@ -193,11 +273,107 @@ func run() error {
 		e.InterceptInterruptSignals()
 	}
 
+	if pick {
+		call, err := e.PickTask()
+		if err != nil {
+			return err
+		}
+		calls = []*task.Call{call}
+	}
+
+	if flags.Describe {
+		return e.DescribeTasks(calls...)
+	}
+
 	ctx := context.Background()
 
 	if flags.Status {
 		return e.Status(ctx, calls...)
 	}
 
-	return e.Run(ctx, calls...)
+	if flags.Why {
+		return e.Why(ctx, calls...)
+	}
+
+	err = e.Run(ctx, calls...)
+	if recorder != nil {
+		if reportErr := writeSummaryReport(log, recorder); reportErr != nil {
+			log.Errf(logger.Red, "task: unable to write summary report: %v\n", reportErr)
+		}
+	}
+	if junit != nil {
+		if reportErr := writeJUnitReport(junit, filepathext.TryAbsToRel(e.Entrypoint)); reportErr != nil {
+			log.Errf(logger.Red, "task: unable to write JUnit report: %v\n", reportErr)
+		}
+	}
+	if trace != nil {
+		if reportErr := writeTrace(trace); reportErr != nil {
+			log.Errf(logger.Red, "task: unable to write trace: %v\n", reportErr)
+		}
+	}
+	return err
+}
+
+// validate prints the problems found in the Taskfile in the format given by
+// the flags. A Taskfile that can't be read is reported as a problem too.
+func validate(log *logger.Logger, e *task.Executor, setupErr error) error {
+	var findings []lint.Finding
+	if setupErr != nil {
+		findings = append(findings, lint.SetupFinding(e.Entrypoint, setupErr))
+	} else {
+		findings = e.Validate()
+	}
+
+	if flags.ListJson {
+		if err := lint.WriteJSON(os.Stdout, findings); err != nil {
+			return err
+		}
+	} else {
+		lint.Print(log, os.Stdout, findings)
+	}
+
+	if errs, _ := lint.Count(findings); errs > 0 {
+		return &errors.TaskfileValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// writeTrace writes the trace of the run to the file given by the flags.
+func writeTrace(trace *report.Trace) error {
+	f, err := os.Create(flags.Trace)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return trace.WriteJSON(f)
+}
+
+// writeJUnitReport writes the JUnit XML report of the run to the file given by
+// the flags.
+func writeJUnitReport(junit *report.JUnit, name string) error {
+	f, err := os.Create(flags.JUnit)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return junit.WriteXML(f, name)
+}
+
+// writeSummaryReport writes the report of the run in the format and to the
+// destination given by the flags.
+func writeSummaryReport(log *logger.Logger, recorder *report.Recorder) error {
+	w := log.Stderr
+	if flags.SummaryReportFile != "" {
+		f, err := os.Create(flags.SummaryReportFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+		log = &logger.Logger{Color: false}
+	}
+	if flags.SummaryReport == "json" {
+		return recorder.WriteJSON(w)
+	}
+	return recorder.PrintTable(log, w)
 }
@@ -1,25 +1,51 @@
 package task
 
-func (e *Executor) acquireConcurrencyLimit() func() {
+import (
+	"context"
+	"time"
+
+	"github.com/go-task/task/v3/internal/events"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func (e *Executor) acquireConcurrencyLimit(ctx context.Context, t *ast.Task) func() {
 	if e.concurrencySemaphore == nil {
 		return emptyFunc
 	}
 
-	e.concurrencySemaphore <- struct{}{}
+	e.waitConcurrencyLimit(ctx, t)
 	return func() {
 		<-e.concurrencySemaphore
 	}
 }
 
-func (e *Executor) releaseConcurrencyLimit() func() {
+func (e *Executor) releaseConcurrencyLimit(ctx context.Context, t *ast.Task) func() {
 	if e.concurrencySemaphore == nil {
 		return emptyFunc
 	}
 
 	<-e.concurrencySemaphore
 	return func() {
-		e.concurrencySemaphore <- struct{}{}
+		e.waitConcurrencyLimit(ctx, t)
+	}
+}
+
+// waitConcurrencyLimit takes a slot of the concurrency semaphore. If no slot is
+// free, an event with the time spent waiting for one is emitted.
+func (e *Executor) waitConcurrencyLimit(ctx context.Context, t *ast.Task) {
+	select {
+	case e.concurrencySemaphore <- struct{}{}:
+		return
+	default:
 	}
+
+	start := time.Now()
+	e.concurrencySemaphore <- struct{}{}
+	e.emit(ctx, events.Event{
+		Type:     events.ConcurrencyWaited,
+		Task:     t.Name(),
+		Duration: time.Since(start),
+	})
 }
 
 func emptyFunc() {}
@@ -0,0 +1,122 @@
+package task
+
+import (
+	"os"
+	"slices"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// checkTaskCycles returns an [errors.TaskCycleError] if the given tasks, or
+// the tasks they call, always call each other in a cycle. References that pass
+// variables, loop or have a condition are ignored, as they are commonly used to
+// recurse on purpose.
+func (e *Executor) checkTaskCycles(roots ...*ast.Task) error {
+	g, cycle, err := e.taskCycle(roots)
+	if err != nil || cycle == nil {
+		return err
+	}
+
+	cycleErr := &errors.TaskCycleError{Cycle: cycle}
+	files := map[string][]byte{}
+	for i := range len(cycle) - 1 {
+		step := errors.TaskCycleStep{From: cycle[i], To: cycle[i+1]}
+		if ref := g.Reference(cycle[i], cycle[i+1]); ref != nil && ref.Location != nil {
+			step.Location = filepathext.TryAbsToRel(ref.Location.Taskfile)
+			step.Line = ref.Location.Line
+			step.Column = ref.Location.Column
+
+			b, ok := files[ref.Location.Taskfile]
+			if !ok {
+				// Remote Taskfiles can't be read again, so they have no snippet
+				b, _ = os.ReadFile(ref.Location.Taskfile)
+				files[ref.Location.Taskfile] = b
+			}
+			if b != nil {
+				step.Snippet = taskfile.NewSnippet(b,
+					taskfile.WithLine(step.Line),
+					taskfile.WithColumn(step.Column),
+					taskfile.WithPadding(1),
+				).String()
+			}
+		}
+		cycleErr.Steps = append(cycleErr.Steps, step)
+	}
+	return cycleErr
+}
+
+// taskCycle returns the graph of the given tasks and the first cycle found in
+// it, if any.
+func (e *Executor) taskCycle(roots []*ast.Task) (*ast.TaskGraph, []string, error) {
+	if len(roots) == 0 {
+		return nil, nil, nil
+	}
+	g, err := e.taskGraph(roots, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	order := make([]string, 0, len(roots))
+	for _, t := range roots {
+		order = append(order, t.Task)
+	}
+	cycle, err := findTaskCycle(g, order)
+	return g, cycle, err
+}
+
+// findTaskCycle returns the first cycle found in the given graph, starting the
+// search from the tasks in the given order. The returned path starts and ends
+// with the same task. If there are no cycles, nil is returned.
+func findTaskCycle(g *ast.TaskGraph, order []string) ([]string, error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(adjacencyMap))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+
+		next := make([]string, 0, len(adjacencyMap[name]))
+		for to := range adjacencyMap[name] {
+			next = append(next, to)
+		}
+		slices.Sort(next)
+		for _, to := range next {
+			switch state[to] {
+			case visiting:
+				start := slices.Index(path, to)
+				return append(slices.Clone(path[start:]), to)
+			case unvisited:
+				if cycle := visit(to); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range order {
+		if _, ok := adjacencyMap[name]; !ok || state[name] != unvisited {
+			continue
+		}
+		if cycle := visit(name); cycle != nil {
+			return cycle, nil
+		}
+	}
+	return nil, nil
+}
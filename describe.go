@@ -0,0 +1,97 @@
+package task
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/go-task/task/v3/internal/editors"
+	"github.com/go-task/task/v3/internal/templater"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// DescribeTasks writes the descriptions of the given tasks, compiled but
+// without running dynamic variables, as JSON to the standard output.
+func (e *Executor) DescribeTasks(calls ...*Call) error {
+	descriptions := make([]editors.TaskDescription, 0, len(calls))
+	for _, call := range calls {
+		t, err := e.describedTask(call)
+		if err != nil {
+			return err
+		}
+		descriptions = append(descriptions, editors.NewTaskDescription(t))
+	}
+
+	encoder := json.NewEncoder(e.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(descriptions)
+}
+
+// describedTask compiles a task like for the task list, but also replaces the
+// variables in the properties that tell what the task does. The commands and
+// dependencies that loop are kept as is, as well as the deferred commands,
+// which may use EXIT_CODE. The variables taken from the environment are left
+// out, as they aren't part of the task and may hold secrets.
+func (e *Executor) describedTask(call *Call) (*ast.Task, error) {
+	origTask, err := e.GetTask(call)
+	if err != nil {
+		return nil, err
+	}
+	t, err := e.CompiledTaskForTaskList(call)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &templater.Cache{Vars: t.Vars}
+	t.Dir = templater.Replace(origTask.Dir, cache)
+	t.Sources = templater.ReplaceGlobs(origTask.Sources, cache)
+	t.Generates = templater.ReplaceGlobs(origTask.Generates, cache)
+
+	t.Env = ast.NewVars()
+	t.Env.Merge(templater.ReplaceVars(e.Taskfile.Env, cache), nil)
+	t.Env.Merge(templater.ReplaceVars(origTask.Env, cache), nil)
+
+	for _, dep := range origTask.Deps {
+		if dep == nil {
+			continue
+		}
+		newDep := dep.DeepCopy()
+		if dep.For == nil {
+			newDep.Task = templater.Replace(dep.Task, cache)
+			newDep.Vars = templater.ReplaceVars(dep.Vars, cache)
+		}
+		t.Deps = append(t.Deps, newDep)
+	}
+	for _, cmd := range origTask.Cmds {
+		if cmd == nil {
+			continue
+		}
+		newCmd := cmd.DeepCopy()
+		if cmd.For == nil && !cmd.Defer {
+			newCmd.Cmd = templater.Replace(cmd.Cmd, cache)
+			newCmd.Task = templater.Replace(cmd.Task, cache)
+			newCmd.If = templater.Replace(cmd.If, cache)
+			newCmd.Vars = templater.ReplaceVars(cmd.Vars, cache)
+		}
+		t.Cmds = append(t.Cmds, newCmd)
+	}
+	for _, precondition := range origTask.Preconditions {
+		if precondition == nil {
+			continue
+		}
+		newPrecondition := precondition.DeepCopy()
+		newPrecondition.Sh = templater.Replace(precondition.Sh, cache)
+		newPrecondition.Msg = templater.Replace(precondition.Msg, cache)
+		t.Preconditions = append(t.Preconditions, newPrecondition)
+	}
+
+	vars := ast.NewVars()
+	for k, v := range t.Vars.All() {
+		if value, ok := os.LookupEnv(k); ok && v.Sh == nil && v.Value == value {
+			continue
+		}
+		vars.Set(k, v)
+	}
+	t.Vars = vars
+
+	return t, nil
+}
@@ -39,6 +39,8 @@ const (
 	CodeTaskCancelled
 	CodeTaskMissingRequiredVars
 	CodeTaskNotAllowedVars
+	CodeTaskTimeout
+	CodeTaskCycle
 )
 
 // TaskError extends the standard error interface with a Code method. This code will
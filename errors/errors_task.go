@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"mvdan.cc/sh/v3/interp"
 )
@@ -43,6 +44,10 @@ func (err *TaskRunError) Error() string {
 }
 
 func (err *TaskRunError) Code() int {
+	var timeoutErr *TaskTimeoutError
+	if errors.As(err.Err, &timeoutErr) {
+		return timeoutErr.Code()
+	}
 	return CodeTaskRunError
 }
 
@@ -58,6 +63,25 @@ func (err *TaskRunError) Unwrap() error {
 	return err.Err
 }
 
+// TaskTimeoutError is returned when a task or one of its commands runs for
+// longer than its configured timeout.
+type TaskTimeoutError struct {
+	TaskName string
+	Command  string
+	Timeout  time.Duration
+}
+
+func (err *TaskTimeoutError) Error() string {
+	if err.Command != "" {
+		return fmt.Sprintf(`task: Command %q in task %q timed out after %s`, err.Command, err.TaskName, err.Timeout)
+	}
+	return fmt.Sprintf(`task: Task %q timed out after %s`, err.TaskName, err.Timeout)
+}
+
+func (err *TaskTimeoutError) Code() int {
+	return CodeTaskTimeout
+}
+
 // TaskInternalError when the user attempts to invoke a task that is internal.
 type TaskInternalError struct {
 	TaskName string
@@ -118,6 +142,45 @@ func (err *TaskCalledTooManyTimesError) Code() int {
 	return CodeTaskCalledTooManyTimes
 }
 
+// TaskCycleError is returned when tasks call each other in a cycle through
+// their deps or cmds, which would never end when running them.
+type TaskCycleError struct {
+	// Cycle is the path of the cycle, starting and ending with the same task
+	Cycle []string
+	// Steps describe where each task of the cycle calls the next one
+	Steps []TaskCycleStep
+}
+
+// TaskCycleStep is a single call from one task to another in a [TaskCycleError].
+type TaskCycleStep struct {
+	From     string
+	To       string
+	Location string
+	Line     int
+	Column   int
+	Snippet  string
+}
+
+func (err *TaskCycleError) Error() string {
+	quoted := make([]string, len(err.Cycle))
+	for i, name := range err.Cycle {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "task: Cycle detected between tasks: %s", strings.Join(quoted, " -> "))
+	for _, step := range err.Steps {
+		fmt.Fprintf(&b, "\n%q calls %q at %s:%d:%d", step.From, step.To, step.Location, step.Line, step.Column)
+		if step.Snippet != "" {
+			fmt.Fprintf(&b, "\n%s", step.Snippet)
+		}
+	}
+	return b.String()
+}
+
+func (err *TaskCycleError) Code() int {
+	return CodeTaskCycle
+}
+
 // TaskCancelledByUserError is returned when the user does not accept an optional prompt to continue.
 type TaskCancelledByUserError struct {
 	TaskName string
@@ -185,6 +248,10 @@ type NotAllowedVar struct {
 	Value string
 	Enum  []string
 	Name  string
+	// Reason tells why the value isn't valid when it is allowed by Enum
+	Reason string
+	// Location is where the variable is declared, as file:line:column
+	Location string
 }
 
 type TaskNotAllowedVarsError struct {
@@ -197,7 +264,15 @@ func (err *TaskNotAllowedVarsError) Error() string {
 
 	builder.WriteString(fmt.Sprintf("task: Task %q cancelled because it is missing required variables:\n", err.TaskName))
 	for _, s := range err.NotAllowedVars {
-		builder.WriteString(fmt.Sprintf("  - %s has an invalid value : '%s' (allowed values : %v)\n", s.Name, s.Value, s.Enum))
+		reason := s.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("allowed values : %v", s.Enum)
+		}
+		builder.WriteString(fmt.Sprintf("  - %s has an invalid value : '%s' (%s)", s.Name, s.Value, reason))
+		if s.Location != "" {
+			builder.WriteString(fmt.Sprintf(", declared at %s", s.Location))
+		}
+		builder.WriteString("\n")
 	}
 
 	return builder.String()
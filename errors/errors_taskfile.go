@@ -212,3 +212,31 @@ func (err *TaskfileDoesNotMatchChecksum) Error() string {
 func (err *TaskfileDoesNotMatchChecksum) Code() int {
 	return CodeTaskfileDoesNotMatchChecksum
 }
+
+// TaskfileValidationError is returned when validating a Taskfile finds
+// errors in it.
+type TaskfileValidationError struct {
+	Errors int
+}
+
+func (err *TaskfileValidationError) Error() string {
+	return fmt.Sprintf("task: Taskfile validation failed with %d error(s)", err.Errors)
+}
+
+func (err *TaskfileValidationError) Code() int {
+	return CodeTaskfileInvalid
+}
+
+// TaskfileNotFormattedError is returned when checking the format of a
+// Taskfile finds that it is not formatted.
+type TaskfileNotFormattedError struct {
+	URI string
+}
+
+func (err *TaskfileNotFormattedError) Error() string {
+	return fmt.Sprintf("task: Taskfile %q is not formatted, run `task --fmt` to format it", err.URI)
+}
+
+func (err *TaskfileNotFormattedError) Code() int {
+	return CodeTaskfileInvalid
+}
@@ -0,0 +1,105 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mvdan.cc/sh/v3/interp"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/events"
+)
+
+// emit sends the given event to all the registered event listeners. The lane
+// of the event is taken from the given context.
+func (e *Executor) emit(ctx context.Context, ev events.Event) {
+	if len(e.EventListeners) == 0 {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	ev.Lane = laneOf(ctx)
+	for _, listener := range e.EventListeners {
+		listener.Handle(ev)
+	}
+}
+
+type laneKey struct{}
+
+// withNewLane returns a copy of ctx with a new lane. It must be called for
+// every goroutine that runs tasks, so that events emitted from different
+// goroutines can be told apart.
+func (e *Executor) withNewLane(ctx context.Context) context.Context {
+	if len(e.EventListeners) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, laneKey{}, int(atomic.AddInt32(&e.laneCount, 1)))
+}
+
+// laneOf returns the lane of the given context. The goroutine that started
+// the run is lane 0.
+func laneOf(ctx context.Context) int {
+	lane, _ := ctx.Value(laneKey{}).(int)
+	return lane
+}
+
+// exitCodeOf returns the exit code that corresponds to the given error, or nil
+// if the error didn't come from a command exiting.
+func exitCodeOf(err error) *int {
+	code := 0
+	if err == nil {
+		return &code
+	}
+	var exitCode interp.ExitStatus
+	if errors.As(err, &exitCode) {
+		code = int(exitCode)
+		return &code
+	}
+	return nil
+}
+
+// maxCapturedOutput is the number of bytes of output that is kept for a failed
+// command when an [events.OutputCapturer] is registered.
+const maxCapturedOutput = 64 * 1024
+
+// capturesOutput returns true if any of the event listeners needs the output of
+// failed commands.
+func (e *Executor) capturesOutput() bool {
+	for _, listener := range e.EventListeners {
+		if c, ok := listener.(events.OutputCapturer); ok && c.CapturesOutput() {
+			return true
+		}
+	}
+	return false
+}
+
+// tailBuffer is a concurrency-safe [io.Writer] that only keeps the last bytes
+// written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+}
+
+func newTailBuffer(limit int) *tailBuffer {
+	return &tailBuffer{limit: limit}
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.limit {
+		b.buf = b.buf[len(b.buf)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
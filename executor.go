@@ -10,9 +10,12 @@ import (
 	"github.com/puzpuzpuz/xsync/v4"
 	"github.com/sajari/fuzzy"
 
+	"github.com/go-task/task/v3/internal/buildcache"
+	"github.com/go-task/task/v3/internal/events"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/output"
 	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/internal/watcher"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -41,7 +44,11 @@ type (
 		CACert              string
 		Cert                string
 		CertKey             string
+		BuildCacheURL       string
+		BuildCacheReadOnly  bool
 		Watch               bool
+		WatchPoll           bool
+		WatchIgnore         []string
 		Verbose             bool
 		Silent              bool
 		DisableFuzzy        bool
@@ -70,17 +77,24 @@ type (
 		TaskSorter         sort.Sorter
 		UserWorkingDir     string
 		EnableVersionCheck bool
+		EventListeners     []events.Listener
 
 		fuzzyModel     *fuzzy.Model
 		fuzzyModelOnce sync.Once
 
+		buildCache     *buildcache.Cache
+		buildCacheErr  error
+		buildCacheOnce sync.Once
+
 		promptedVars         *ast.Vars // vars collected via interactive prompts
 		concurrencySemaphore chan struct{}
 		taskCallCount        map[string]*int32
+		laneCount            int32
 		mkdirMutexMap        map[string]*sync.Mutex
 		executionHashes      map[string]context.Context
 		executionHashesMutex sync.Mutex
 		watchedDirs          *xsync.Map[string, bool]
+		watchIgnorer         *watcher.Ignorer
 	}
 	TempDir struct {
 		Remote      string
@@ -329,6 +343,36 @@ func (o *certKeyOption) ApplyToExecutor(e *Executor) {
 	e.CertKey = o.certKey
 }
 
+// WithBuildCacheURL sets the URL of a server that shares the build cache of
+// the tasks that set cache to true between machines. The TLS settings given to
+// [WithInsecure], [WithCACert], [WithCert] and [WithCertKey] are used to
+// connect to it.
+func WithBuildCacheURL(url string) ExecutorOption {
+	return &buildCacheURLOption{url: url}
+}
+
+type buildCacheURLOption struct {
+	url string
+}
+
+func (o *buildCacheURLOption) ApplyToExecutor(e *Executor) {
+	e.BuildCacheURL = o.url
+}
+
+// WithBuildCacheReadOnly tells the [Executor] to only read from the server
+// given to [WithBuildCacheURL], without storing the outputs of tasks on it.
+func WithBuildCacheReadOnly(readOnly bool) ExecutorOption {
+	return &buildCacheReadOnlyOption{readOnly: readOnly}
+}
+
+type buildCacheReadOnlyOption struct {
+	readOnly bool
+}
+
+func (o *buildCacheReadOnlyOption) ApplyToExecutor(e *Executor) {
+	e.BuildCacheReadOnly = o.readOnly
+}
+
 // WithWatch tells the [Executor] to keep running in the background and watch
 // for changes to the fingerprint of the tasks that are run. When changes are
 // detected, a new task run is triggered.
@@ -344,6 +388,37 @@ func (o *watchOption) ApplyToExecutor(e *Executor) {
 	e.Watch = o.watch
 }
 
+// WithWatchPoll tells the [Executor] to watch for changes by polling the file
+// system at the watch interval, instead of relying on its events. This works
+// on file systems that don't emit events, like network shares.
+func WithWatchPoll(poll bool) ExecutorOption {
+	return &watchPollOption{poll}
+}
+
+type watchPollOption struct {
+	poll bool
+}
+
+func (o *watchPollOption) ApplyToExecutor(e *Executor) {
+	e.WatchPoll = o.poll
+}
+
+// WithWatchIgnore sets patterns of directories that are not watched, in
+// addition to the ones of the .gitignore and .taskignore files. They use the
+// syntax of .gitignore files and are relative to the directory of the
+// Taskfile.
+func WithWatchIgnore(patterns []string) ExecutorOption {
+	return &watchIgnoreOption{patterns}
+}
+
+type watchIgnoreOption struct {
+	patterns []string
+}
+
+func (o *watchIgnoreOption) ApplyToExecutor(e *Executor) {
+	e.WatchIgnore = o.patterns
+}
+
 // WithVerbose tells the [Executor] to output more information about the tasks
 // that are run.
 func WithVerbose(verbose bool) ExecutorOption {
@@ -617,3 +692,17 @@ type failfastOption struct {
 func (o *failfastOption) ApplyToExecutor(e *Executor) {
 	e.Failfast = o.failfast
 }
+
+// WithEventListener adds a listener that receives the lifecycle events of the
+// tasks and commands run by the [Executor]. It can be given multiple times.
+func WithEventListener(listener events.Listener) ExecutorOption {
+	return &eventListenerOption{listener}
+}
+
+type eventListenerOption struct {
+	listener events.Listener
+}
+
+func (o *eventListenerOption) ApplyToExecutor(e *Executor) {
+	e.EventListeners = append(e.EventListeners, o.listener)
+}
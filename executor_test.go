@@ -3,9 +3,12 @@ package task_test
 import (
 	"bytes"
 	"cmp"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/sebdah/goldie/v2"
@@ -13,7 +16,10 @@ import (
 
 	"github.com/go-task/task/v3"
 	"github.com/go-task/task/v3/experiments"
+	"github.com/go-task/task/v3/internal/editors"
+	"github.com/go-task/task/v3/internal/events"
 	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/taskgraph"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -351,6 +357,28 @@ func TestRequires(t *testing.T) {
 		),
 		WithTask("var-defined-in-task"),
 	)
+	NewExecutorTest(t,
+		WithName("typed vars"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("typed-vars"),
+		WithVar("PORT", "8080"),
+		WithVar("VERSION", "v1.2.0"),
+		WithVar("NAME", "api"),
+	)
+	NewExecutorTest(t,
+		WithName("typed vars fail validation"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("typed-vars"),
+		WithVar("PORT", "70000"),
+		WithVar("VERSION", "0.9.0"),
+		WithVar("TIMEOUT", "soon"),
+		WithVar("NAME", "API"),
+		WithRunError(),
+	)
 }
 
 // TODO: mock fs
@@ -1184,3 +1212,243 @@ func TestIf(t *testing.T) {
 		NewExecutorTest(t, opts...)
 	}
 }
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, taskName string) ([]string, error) {
+		t.Helper()
+		var (
+			mu  sync.Mutex
+			got []string
+		)
+		listener := events.ListenerFunc(func(ev events.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			s := fmt.Sprintf("%s %s", ev.Type, ev.Task)
+			if ev.Command != "" {
+				s += fmt.Sprintf(" %q", ev.Command)
+			}
+			if ev.Reason != "" {
+				s += " " + ev.Reason
+			}
+			if ev.ExitCode != nil {
+				s += fmt.Sprintf(" %d", *ev.ExitCode)
+			}
+			if ev.Lane != 0 {
+				s += fmt.Sprintf(" (lane %d)", ev.Lane)
+			}
+			got = append(got, s)
+		})
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir("testdata/events"),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithEventListener(listener),
+		)
+		require.NoError(t, e.Setup())
+		err := e.Run(t.Context(), &task.Call{Task: taskName})
+		return got, err
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := run(t, "default")
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"task_started default",
+			"task_started up-to-date (lane 1)",
+			"task_up_to_date up-to-date (lane 1)",
+			"task_finished up-to-date 0 (lane 1)",
+			`command_started default "echo hello"`,
+			`command_finished default "echo hello" 0`,
+			"task_skipped skipped if",
+			`deferred_ran default "echo cleanup" 0`,
+			"task_finished default 0",
+		}, got)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := run(t, "failing")
+		require.Error(t, err)
+		require.Equal(t, []string{
+			"task_started failing",
+			`command_started failing "exit 3"`,
+			`command_finished failing "exit 3" 3`,
+			"task_finished failing 3",
+		}, got)
+	})
+
+	t.Run("precondition", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := run(t, "precondition")
+		require.Error(t, err)
+		require.Equal(t, []string{
+			"task_started precondition",
+			`precondition_failed precondition "false" not met 1`,
+			"task_finished precondition",
+		}, got)
+	})
+}
+
+type outputCapturer struct {
+	mu      sync.Mutex
+	outputs []string
+}
+
+func (c *outputCapturer) Handle(ev events.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ev.Type == events.CommandFinished {
+		c.outputs = append(c.outputs, ev.Output)
+	}
+}
+
+func (c *outputCapturer) CapturesOutput() bool {
+	return true
+}
+
+func TestEventsCapturedOutput(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	capturer := &outputCapturer{}
+	e := task.NewExecutor(
+		task.WithDir("testdata/events"),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithSilent(true),
+		task.WithEventListener(capturer),
+	)
+	require.NoError(t, e.Setup())
+	require.Error(t, e.Run(t.Context(), &task.Call{Task: "failing-with-output"}))
+	require.Equal(t, []string{"", "failure output\n"}, capturer.outputs)
+	require.Equal(t, "some output\nfailure output\n", buff.String())
+}
+
+func TestTaskGraph(t *testing.T) {
+	t.Parallel()
+
+	e := task.NewExecutor(
+		task.WithDir("testdata/graph"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		tg, err := e.TaskGraph()
+		require.NoError(t, err)
+		g := goldie.New(t, goldie.WithFixtureDir("testdata/graph/testdata"))
+		for _, format := range []string{taskgraph.FormatDOT, taskgraph.FormatMermaid} {
+			var buff bytes.Buffer
+			require.NoError(t, taskgraph.Write(&buff, tg, format))
+			g.Assert(t, "TestTaskGraph-"+format, buff.Bytes())
+		}
+	})
+
+	t.Run("calls", func(t *testing.T) {
+		t.Parallel()
+
+		tg, err := e.TaskGraph(&task.Call{Task: "docs:build"}, &task.Call{Task: "l"})
+		require.NoError(t, err)
+		g, err := taskgraph.New(tg)
+		require.NoError(t, err)
+
+		var nodes []string
+		for _, node := range g.Nodes {
+			nodes = append(nodes, node.Name)
+		}
+		require.Equal(t, []string{"docs:build", "docs:serve", "generate", "lint"}, nodes)
+		require.Equal(t, []taskgraph.Edge{
+			{From: "docs:build", To: "docs:serve", Type: "cmd"},
+			{From: "docs:build", To: "generate", Type: "dep"},
+		}, g.Edges)
+	})
+}
+
+func TestDescribeTasks(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir("testdata/describe"),
+		task.WithStdout(&buff),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
+
+	vars := ast.NewVars()
+	vars.Set("MODE", ast.Var{Value: "debug"})
+	require.NoError(t, e.DescribeTasks(&task.Call{Task: "b", Vars: vars}))
+
+	var descriptions []editors.TaskDescription
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &descriptions))
+	require.Len(t, descriptions, 1)
+	d := descriptions[0]
+
+	require.Equal(t, "build", d.Name)
+	require.Equal(t, "Builds bin/app", d.Desc)
+	require.Equal(t, []string{"b"}, d.Aliases)
+	require.Equal(t, 14, d.Location.Line)
+	require.Contains(t, d.Vars, editors.Var{Name: "MODE", Value: "debug"})
+	goos := "go env GOOS"
+	require.Contains(t, d.Vars, editors.Var{Name: "GOOS", Sh: &goos})
+	require.Equal(t, []editors.Var{
+		{Name: "CGO_ENABLED", Value: "0"},
+		{Name: "OUTPUT", Value: "bin/app"},
+	}, d.Env)
+	require.Equal(t, []editors.Dep{{Task: "generate"}}, d.Deps)
+	require.Equal(t, []editors.Cmd{
+		{Cmd: "go build -o bin/app ."},
+		{Task: "generate", Vars: []editors.Var{{Name: "OUT", Value: "bin/app"}}},
+		{Cmd: "echo {{.ITEM}}", For: true},
+		{Cmd: "echo {{.EXIT_CODE}}", Defer: true},
+	}, d.Cmds)
+	require.Equal(t, []string{"**/*.go", "!bin/app"}, d.Sources)
+	require.Equal(t, []string{"bin/app"}, d.Generates)
+	require.Equal(t, []editors.Requirement{{Name: "MODE", Enum: []string{"debug", "release"}}}, d.Requires)
+	require.Len(t, d.Preconditions, 1)
+	require.Equal(t, "test -f go.mod", d.Preconditions[0].Sh)
+	require.Equal(t, []string{"linux", "darwin/arm64"}, d.Platforms)
+
+	for _, v := range d.Vars {
+		require.NotEqual(t, "HOME", v.Name, "variables of the environment are left out")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	e := task.NewExecutor(
+		task.WithDir("testdata/validate"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
+
+	var findings []string
+	for _, f := range e.Validate() {
+		findings = append(findings, fmt.Sprintf("%s: %s: %s (%s)", f.Location, f.Severity, f.Message, f.Rule))
+	}
+	require.Equal(t, []string{
+		`testdata/validate/Taskfile.yml:11:19: error: task "biuld" does not exist. Did you mean "build"? (missing-task)`,
+		`testdata/validate/Taskfile.yml:13:9: error: task "lib:deploy" is called with ENV="staging", which is not one of the allowed values [dev prod] (enum-value)`,
+		`testdata/validate/Taskfile.yml:20:9: warning: variable "MISSING" is not defined (unknown-var)`,
+		`testdata/validate/Taskfile.yml:21:9: error: invalid template: template: :1: unclosed action (invalid-template)`,
+		`testdata/validate/Taskfile.yml:23:3: warning: alias "test" of task "build" is shadowed by the task of the same name (shadowed-alias)`,
+		`testdata/validate/Taskfile.yml:29:9: warning: command never runs, as its platforms don't match the ones of task "build" (platforms)`,
+		`testdata/validate/Taskfile.yml:34:3: error: alias "t" is used by tasks "check", "test" (duplicate-alias)`,
+		`testdata/validate/Taskfile.yml:44:3: warning: internal task "helper" is never called by another task (unused-internal)`,
+		`testdata/validate/Taskfile.yml:50:12: error: tasks call each other in a cycle: "loop-a" -> "loop-b" -> "loop-a" (task-cycle)`,
+		`testdata/validate/Taskfile.yml:63:11: warning: no caller of task "release" sets CHANNEL to one of the allowed values [stable beta] (enum-value)`,
+		`testdata/validate/included/Taskfile.yml:20:9: warning: variable "WHO" is not defined (unknown-var)`,
+	}, findings)
+}
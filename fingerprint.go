@@ -0,0 +1,163 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Ladicle/tabwriter"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/internal/sort"
+)
+
+type (
+	// SourcesCheckable is implemented by the methods that check whether the
+	// sources of a task are up-to-date.
+	SourcesCheckable = fingerprint.SourcesCheckable
+	// SourcesCheckerFactory returns a new [SourcesCheckable] that keeps its
+	// state in the given temporary directory. If dry is true, the checker must
+	// not write anything.
+	SourcesCheckerFactory = fingerprint.SourcesCheckerFactory
+)
+
+// RegisterSourcesChecker makes a custom fingerprinting method available to the
+// method field of Taskfiles and tasks. It should be called before any task is
+// run, usually from an init function. It returns an error if the method is
+// empty or already registered, including the built-in ones.
+func RegisterSourcesChecker(method string, factory SourcesCheckerFactory) error {
+	return fingerprint.RegisterSourcesChecker(method, factory)
+}
+
+// CleanFingerprints removes the fingerprints stored for tasks, so that they
+// run again. Without names, it removes the fingerprints of the tasks that no
+// longer exist, as well as the files of the build cache that weren't used for a
+// while. Otherwise, it removes those of the given tasks and of the tasks in the
+// given namespaces. Every fingerprint is printed along with its task and its
+// size. In dry mode, nothing is removed.
+func (e *Executor) CleanFingerprints(names ...string) error {
+	entries, err := fingerprint.Entries(e.TempDir.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	owners := e.fingerprintOwners()
+	for i, name := range names {
+		names[i] = strings.TrimSuffix(name, ":")
+		if !slices.ContainsFunc(owners, func(o fingerprintOwner) bool { return inNamespace(o.task, names[i]) }) {
+			return &errors.TaskNotFoundError{TaskName: name}
+		}
+	}
+
+	if len(entries) == 0 {
+		e.Logger.Outf(logger.Default, "task: No fingerprints stored in %q\n", e.TempDir.Fingerprint)
+		return e.pruneBuildCache(names)
+	}
+
+	var removed int
+	var removedSize, keptSize int64
+	w := tabwriter.NewWriter(e.Stdout, 0, 8, 2, ' ', 0)
+	e.Logger.FOutf(w, logger.Default, "ENTRY\tTASK\tSIZE\n")
+	for _, entry := range entries {
+		task := ""
+		for _, o := range owners {
+			if o.pattern.MatchString(entry.Name) {
+				task = o.task
+				break
+			}
+		}
+
+		remove := task == "" && len(names) == 0
+		for _, name := range names {
+			remove = remove || (task != "" && inNamespace(task, name))
+		}
+
+		e.Logger.FOutf(w, logger.Default, "%s/%s\t", entry.Method, entry.Name)
+		if task == "" {
+			e.Logger.FOutf(w, logger.Yellow, "(orphan)\t")
+		} else {
+			e.Logger.FOutf(w, logger.Green, "%s\t", task)
+		}
+		e.Logger.FOutf(w, logger.Default, "%s", formatBytes(entry.Size))
+		if !remove {
+			keptSize += entry.Size
+			e.Logger.FOutf(w, logger.Default, "\n")
+			continue
+		}
+		removed++
+		removedSize += entry.Size
+		if e.Dry {
+			e.Logger.FOutf(w, logger.Yellow, "\twould be removed\n")
+			continue
+		}
+		if err := entry.Remove(); err != nil {
+			return err
+		}
+		e.Logger.FOutf(w, logger.Yellow, "\tremoved\n")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	verb := "Removed"
+	if e.Dry {
+		verb = "Would remove"
+	}
+	e.Logger.Outf(logger.Default, "task: %s %d of %d fingerprints (%s), %s left\n",
+		verb, removed, len(entries), formatBytes(removedSize), formatBytes(keptSize))
+	return e.pruneBuildCache(names)
+}
+
+// fingerprintOwner matches the fingerprints stored for a task
+type fingerprintOwner struct {
+	task    string
+	pattern *regexp.Regexp
+}
+
+// fingerprintOwners returns the patterns of the fingerprints of every task.
+// Fingerprints are stored by task name or label. Names without templates or
+// wildcards come first, so that they win over the patterns that match more.
+func (e *Executor) fingerprintOwners() []fingerprintOwner {
+	var owners []fingerprintOwner
+	for t := range e.Taskfile.Tasks.Values(sort.AlphaNumeric) {
+		for _, name := range []string{t.Task, t.Label} {
+			if name != "" {
+				owners = append(owners, fingerprintOwner{task: t.Task, pattern: fingerprint.NamePattern(name)})
+			}
+		}
+	}
+	slices.SortStableFunc(owners, func(a, b fingerprintOwner) int {
+		aExact := !strings.Contains(a.pattern.String(), ".*")
+		bExact := !strings.Contains(b.pattern.String(), ".*")
+		switch {
+		case aExact && !bExact:
+			return -1
+		case !aExact && bExact:
+			return 1
+		}
+		return 0
+	})
+	return owners
+}
+
+// inNamespace returns true if the task is the given one or is in the given
+// namespace.
+func inNamespace(task, name string) bool {
+	return task == name || strings.HasPrefix(task, name+":")
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
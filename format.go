@@ -0,0 +1,53 @@
+package task
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/format"
+	"github.com/go-task/task/v3/taskfile"
+)
+
+// FormatTaskfile formats the entrypoint Taskfile, without the Taskfiles it
+// includes. The Taskfile is rewritten in place, unless it is read from stdin,
+// in which case it is written formatted to stdout. If check is true, nothing
+// is written and an error is returned if the Taskfile is not formatted.
+func (e *Executor) FormatTaskfile(check bool) error {
+	node, err := e.getRootNode()
+	if err != nil {
+		return err
+	}
+	if _, ok := node.(taskfile.RemoteNode); ok {
+		return errors.New("task: Remote Taskfiles can't be formatted")
+	}
+
+	b, err := node.Read()
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Taskfile(b)
+	if err != nil {
+		return &errors.TaskfileInvalidError{URI: filepathext.TryAbsToRel(node.Location()), Err: err}
+	}
+
+	_, stdin := node.(*taskfile.StdinNode)
+	switch {
+	case check && !bytes.Equal(b, formatted):
+		return &errors.TaskfileNotFormattedError{URI: filepathext.TryAbsToRel(node.Location())}
+	case check:
+		return nil
+	case stdin:
+		_, err := e.Stdout.Write(formatted)
+		return err
+	case bytes.Equal(b, formatted):
+		return nil
+	}
+
+	info, err := os.Stat(node.Location())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(node.Location(), formatted, info.Mode().Perm())
+}
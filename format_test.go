@@ -0,0 +1,40 @@
+package task_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3"
+	"github.com/go-task/task/v3/errors"
+)
+
+func TestFormatTaskfile(t *testing.T) {
+	t.Parallel()
+
+	const (
+		unformatted = "tasks:\n  default:\n    cmds: [\"echo hi\"]\n    desc: Says hi\nversion: \"3\"\n"
+		formatted   = "version: '3'\n\ntasks:\n  default:\n    desc: Says hi\n    cmds: [echo hi]\n"
+	)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Taskfile.yml")
+	require.NoError(t, os.WriteFile(path, []byte(unformatted), 0o644))
+
+	e := task.NewExecutor(task.WithDir(dir))
+	var notFormatted *errors.TaskfileNotFormattedError
+	require.ErrorAs(t, e.FormatTaskfile(true), &notFormatted)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, unformatted, string(b), "--check must not change the Taskfile")
+
+	require.NoError(t, task.NewExecutor(task.WithDir(dir)).FormatTaskfile(false))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, formatted, string(b))
+
+	require.NoError(t, task.NewExecutor(task.WithDir(dir)).FormatTaskfile(true))
+}
@@ -0,0 +1,120 @@
+package task
+
+import (
+	"iter"
+	"strings"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// TaskGraph returns the graph of the tasks that call each other through their
+// deps and cmds. If calls are given, only the tasks that are reachable from
+// them are included. Otherwise, the graph has every task of the Taskfile,
+// including the ones from included Taskfiles.
+//
+// References that cannot be resolved without running the task, like task names
+// that depend on variables, are left out.
+func (e *Executor) TaskGraph(calls ...*Call) (*ast.TaskGraph, error) {
+	var roots []*ast.Task
+	for _, call := range calls {
+		t, err := e.GetTask(call)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, t)
+	}
+	return e.taskGraph(roots, false)
+}
+
+// taskGraph builds the graph of the tasks reachable from the given ones, or of
+// all the tasks if none are given. If unconditionalOnly is true, references
+// that may not call the task every time, because they pass variables, loop or
+// have a condition, are left out.
+func (e *Executor) taskGraph(roots []*ast.Task, unconditionalOnly bool) (*ast.TaskGraph, error) {
+	g := ast.NewTaskGraph()
+
+	queue := roots
+	if len(queue) == 0 {
+		for t := range e.Taskfile.Tasks.Values(nil) {
+			queue = append(queue, t)
+		}
+	}
+
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if visited[t.Task] {
+			continue
+		}
+		visited[t.Task] = true
+		if err := g.AddTask(t); err != nil {
+			return nil, err
+		}
+
+		for ref := range taskReferences(t) {
+			dep := e.resolveTaskReference(ref.name)
+			if dep == nil {
+				continue
+			}
+			if unconditionalOnly && (ref.conditional || strings.TrimSpace(dep.If) != "") {
+				continue
+			}
+			if err := g.AddReference(t, dep, ref.TaskReference); err != nil {
+				return nil, err
+			}
+			queue = append(queue, dep)
+		}
+	}
+
+	return g, nil
+}
+
+type taskReference struct {
+	*ast.TaskReference
+	name        string
+	conditional bool
+}
+
+// taskReferences returns an iterator over the tasks that the given task calls,
+// in the order they appear in the Taskfile.
+func taskReferences(t *ast.Task) iter.Seq[taskReference] {
+	return func(yield func(taskReference) bool) {
+		for _, dep := range t.Deps {
+			if dep == nil || dep.Task == "" {
+				continue
+			}
+			ref := taskReference{
+				TaskReference: &ast.TaskReference{Kind: ast.TaskEdgeDep, Location: dep.Location},
+				name:          dep.Task,
+				conditional:   dep.For != nil || dep.Vars.Len() > 0,
+			}
+			if !yield(ref) {
+				return
+			}
+		}
+		for _, cmd := range t.Cmds {
+			if cmd == nil || cmd.Task == "" {
+				continue
+			}
+			ref := taskReference{
+				TaskReference: &ast.TaskReference{Kind: ast.TaskEdgeCmd, Location: cmd.Location},
+				name:          cmd.Task,
+				conditional:   cmd.For != nil || cmd.Vars.Len() > 0 || strings.TrimSpace(cmd.If) != "",
+			}
+			if !yield(ref) {
+				return
+			}
+		}
+	}
+}
+
+// resolveTaskReference returns the task that a reference with the given name
+// calls, or nil if no task matches it.
+func (e *Executor) resolveTaskReference(name string) *ast.Task {
+	matchingTasks, err := e.FindMatchingTasks(&Call{Task: name})
+	if err != nil || len(matchingTasks) == 0 {
+		return nil
+	}
+	return matchingTasks[0].Task
+}
@@ -0,0 +1,202 @@
+package buildcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Prefixes of the keys of the blobs in a [Store]. Action entries map the key
+// of a task to the manifest of its outputs, while the content-addressed
+// storage holds the contents of the outputs by digest.
+const (
+	prefixAction  = "ac/"
+	prefixContent = "cas/"
+)
+
+// ErrDigestMismatch is returned when the contents of a blob of the
+// content-addressed storage don't match the digest they are stored under.
+var ErrDigestMismatch = errors.New("task: build cache contents don't match their digest")
+
+// A Store holds the blobs of a [Cache] by key.
+type Store interface {
+	// Get returns the contents of the blob with the given key. If there is no
+	// such blob, the returned error wraps [fs.ErrNotExist].
+	Get(key string) (io.ReadCloser, error)
+	// Put stores the given contents under the given key.
+	Put(key string, r io.Reader) error
+}
+
+// A deleter is a [Store] that can delete blobs, which is used to drop the ones
+// that turn out to be corrupted.
+type deleter interface {
+	Delete(key string) error
+}
+
+// Cache stores the files generated by tasks, so that they can be restored
+// instead of running the task again when its inputs didn't change.
+type Cache struct {
+	store Store
+}
+
+// New returns a cache that keeps its blobs in the given store.
+func New(store Store) *Cache {
+	return &Cache{store: store}
+}
+
+type (
+	manifest struct {
+		Files []manifestFile `json:"files"`
+	}
+	manifestFile struct {
+		Path   string      `json:"path"`
+		Mode   fs.FileMode `json:"mode"`
+		Digest string      `json:"digest"`
+	}
+)
+
+// Restore writes the files stored under the given key to dir. It returns false
+// if nothing is stored under the key or if some of its files are missing.
+func (c *Cache) Restore(dir, key string) (bool, error) {
+	r, err := c.store.Get(prefixAction + key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	var m manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return false, fmt.Errorf("task: invalid cache entry %q: %w", key, err)
+	}
+	// Manifests may come from a remote server, so their paths can't be trusted
+	// to stay inside of dir
+	for _, f := range m.Files {
+		if !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			return false, fmt.Errorf("task: invalid cache entry %q: path %q is outside of the directory", key, f.Path)
+		}
+	}
+	for _, f := range m.Files {
+		ok, err := c.restoreFile(filepath.Join(dir, filepath.FromSlash(f.Path)), f)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (c *Cache) restoreFile(path string, f manifestFile) (bool, error) {
+	r, err := c.store.Get(prefixContent + f.Digest)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	if err := writeFile(path, r, f.Mode, f.Digest); err != nil {
+		if d, ok := c.store.(deleter); ok && errors.Is(err, ErrDigestMismatch) {
+			_ = d.Delete(prefixContent + f.Digest)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Save stores the given files under the given key. The paths of the files are
+// stored relative to dir, so that they can be restored somewhere else.
+func (c *Cache) Save(dir, key string, files []string) error {
+	m := manifest{Files: make([]manifestFile, 0, len(files))}
+	for _, path := range slices.Sorted(slices.Values(files)) {
+		f, err := c.saveFile(path)
+		if err != nil {
+			return err
+		}
+		if f.Path, err = filepath.Rel(dir, path); err != nil {
+			return err
+		}
+		f.Path = filepath.ToSlash(f.Path)
+		m.Files = append(m.Files, f)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.store.Put(prefixAction+key, bytes.NewReader(b))
+}
+
+func (c *Cache) saveFile(path string) (manifestFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return manifestFile{}, err
+	}
+	digest, err := FileDigest(path)
+	if err != nil {
+		return manifestFile{}, err
+	}
+	r, err := os.Open(path)
+	if err != nil {
+		return manifestFile{}, err
+	}
+	defer r.Close()
+	if err := c.store.Put(prefixContent+digest, r); err != nil {
+		return manifestFile{}, err
+	}
+	return manifestFile{Mode: info.Mode().Perm(), Digest: digest}, nil
+}
+
+// FileDigest returns the SHA-256 digest of the contents of the given file.
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeFile writes the contents of r to path. The contents are first written
+// to a temporary file which is then renamed, so that path is never left half
+// written. If digest isn't empty, path is only written if the SHA-256 digest of
+// the contents matches it.
+func writeFile(path string, r io.Reader, mode fs.FileMode, digest string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if digest != "" && hex.EncodeToString(h.Sum(nil)) != digest {
+		return fmt.Errorf("%w: %s", ErrDigestMismatch, digest)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,103 @@
+package buildcache_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/buildcache"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	files := []string{filepath.Join(src, "a.txt"), filepath.Join(src, "bin", "b")}
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "bin"), 0o755))
+	require.NoError(t, os.WriteFile(files[0], []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(files[1], []byte("b"), 0o755))
+
+	cache := buildcache.New(buildcache.NewDirStore(t.TempDir()))
+	require.NoError(t, cache.Save(src, "key", files))
+
+	dst := t.TempDir()
+	restored, err := cache.Restore(dst, "other")
+	require.NoError(t, err)
+	assert.False(t, restored)
+
+	restored, err = cache.Restore(dst, "key")
+	require.NoError(t, err)
+	assert.True(t, restored)
+
+	b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(b))
+	info, err := os.Stat(filepath.Join(dst, "bin", "b"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+}
+
+func TestCacheRestoreOutsideOfDir(t *testing.T) {
+	t.Parallel()
+
+	store := buildcache.NewDirStore(t.TempDir())
+	cache := buildcache.New(store)
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, cache.Save(src, "key", []string{filepath.Join(src, "a.txt")}))
+	digest, err := buildcache.FileDigest(filepath.Join(src, "a.txt"))
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "dst")
+	for _, path := range []string{"../a.txt", "b/../../a.txt", "/tmp/a.txt", ""} {
+		manifest := fmt.Sprintf(`{"files":[{"path":%q,"mode":420,"digest":%q}]}`, path, digest)
+		require.NoError(t, store.Put("ac/evil", strings.NewReader(manifest)))
+
+		restored, err := cache.Restore(dst, "evil")
+		require.Error(t, err, path)
+		assert.False(t, restored, path)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(dst), "a.txt"), path)
+	}
+}
+
+func TestDirStorePrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store := buildcache.NewDirStore(dir)
+	require.NoError(t, store.Put("ac/used", strings.NewReader("used")))
+	require.NoError(t, store.Put("ac/unused", strings.NewReader("unused")))
+	old := time.Now().Add(-time.Hour)
+	for _, name := range []string{"used", "unused"} {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "ac", name), old, old))
+	}
+
+	// Reading a blob marks it as used
+	r, err := store.Get("ac/used")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	before := time.Now().Add(-time.Minute)
+	n, size, err := store.Prune(before, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(len("unused")), size)
+	assert.FileExists(t, filepath.Join(dir, "ac", "unused"))
+
+	n, _, err = store.Prune(before, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoFileExists(t, filepath.Join(dir, "ac", "unused"))
+	assert.FileExists(t, filepath.Join(dir, "ac", "used"))
+
+	// A store that was never written to has nothing to prune
+	n, _, err = buildcache.NewDirStore(filepath.Join(dir, "missing")).Prune(before, false)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,103 @@
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+type (
+	// definition holds everything that the outputs of a task depend on
+	definition struct {
+		Task      string         `json:"task"`
+		Dir       string         `json:"dir"`
+		Cmds      []command      `json:"cmds"`
+		Set       []string       `json:"set,omitempty"`
+		Shopt     []string       `json:"shopt,omitempty"`
+		Sources   []*ast.Glob    `json:"sources,omitempty"`
+		Generates []*ast.Glob    `json:"generates"`
+		Env       map[string]any `json:"env,omitempty"`
+		Vars      map[string]any `json:"vars,omitempty"`
+		Files     []source       `json:"files,omitempty"`
+	}
+	command struct {
+		Cmd  string         `json:"cmd,omitempty"`
+		Task string         `json:"task,omitempty"`
+		Vars map[string]any `json:"vars,omitempty"`
+	}
+	source struct {
+		Path   string `json:"path"`
+		Digest string `json:"digest"`
+	}
+)
+
+// Key returns the key that the outputs of the given compiled task are stored
+// under. It changes whenever the contents of the sources, the definition of
+// the task or the given vars change. Paths are made relative to rootDir, so
+// that the same task has the same key in different copies of a project.
+func Key(t *ast.Task, rootDir string, vars map[string]any) (string, error) {
+	dir, err := relPath(rootDir, t.Dir)
+	if err != nil {
+		return "", err
+	}
+	def := definition{
+		Task:      t.Task,
+		Dir:       filepath.ToSlash(dir),
+		Set:       t.Set,
+		Shopt:     t.Shopt,
+		Sources:   t.Sources,
+		Generates: t.Generates,
+		Vars:      vars,
+	}
+	if t.Env != nil {
+		def.Env = t.Env.ToCacheMap()
+	}
+	for _, cmd := range t.Cmds {
+		c := command{Cmd: cmd.Cmd, Task: cmd.Task}
+		if cmd.Vars != nil {
+			c.Vars = cmd.Vars.ToCacheMap()
+		}
+		def.Cmds = append(def.Cmds, c)
+	}
+
+	sources, err := fingerprint.Globs(t.Dir, t.Sources)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range sources {
+		digest, err := FileDigest(path)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(t.Dir, path)
+		if err != nil {
+			return "", err
+		}
+		def.Files = append(def.Files, source{Path: filepath.ToSlash(rel), Digest: digest})
+	}
+
+	b, err := json.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// relPath is like [filepath.Rel], but it also works when only one of the paths
+// is absolute.
+func relPath(base, target string) (string, error) {
+	base, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(base, target)
+}
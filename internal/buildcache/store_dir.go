@@ -0,0 +1,92 @@
+package buildcache
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirStore is a [Store] that keeps its blobs as files in a directory.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a store that keeps its blobs in the given directory. The
+// directory is created when the first blob is stored.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+func (s *DirStore) Get(key string) (io.ReadCloser, error) {
+	path := s.path(key)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	touch(path)
+	return f, nil
+}
+
+func (s *DirStore) Put(key string, r io.Reader) error {
+	path := s.path(key)
+	// Contents are addressed by their digest, so they never change once stored,
+	// and they are only stored if they match it
+	var digest string
+	if strings.HasPrefix(key, prefixContent) {
+		if _, err := os.Stat(path); err == nil {
+			touch(path)
+			return nil
+		}
+		digest = strings.TrimPrefix(key, prefixContent)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFile(path, r, 0o644, digest)
+}
+
+func (s *DirStore) Delete(key string) error {
+	return os.Remove(s.path(key))
+}
+
+// Prune removes the blobs that weren't used since the given time, and returns
+// their number and total size. If dry is true, nothing is removed.
+func (s *DirStore) Prune(before time.Time, dry bool) (n int, size int64, err error) {
+	err = filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if path == s.dir && errors.Is(err, fs.ErrNotExist) {
+			return fs.SkipAll
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+		n++
+		size += info.Size()
+		if dry {
+			return nil
+		}
+		return os.Remove(path)
+	})
+	return n, size, err
+}
+
+// touch sets the modification time of the blob at the given path to now, so
+// that it tells when the blob was last used
+func touch(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+func (s *DirStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
@@ -0,0 +1,141 @@
+package buildcache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// HTTPStore is a [Store] that keeps its blobs on a remote server. Blobs are
+// read with a GET and written with a PUT request to the URL of the server
+// followed by their key, like https://cache.example.com/cas/<digest>. A
+// server answering 404 to a GET means that it doesn't have the blob.
+type HTTPStore struct {
+	url      *url.URL
+	client   *http.Client
+	readOnly bool
+	timeout  time.Duration
+}
+
+// NewHTTPStore returns a store that keeps its blobs on the server at the given
+// URL. If readOnly is true, blobs are never written to the server, which is
+// useful to let machines use the cache without populating it. Like for remote
+// Taskfiles, plain HTTP URLs are rejected unless insecure is true.
+func NewHTTPStore(rawURL string, client *http.Client, readOnly, insecure bool) (*HTTPStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("task: unsupported build cache URL %q", rawURL)
+	}
+	if u.Scheme == "http" && !insecure {
+		return nil, &errors.TaskfileNotSecureError{URI: u.Redacted()}
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPStore{
+		url:      u,
+		client:   client,
+		readOnly: readOnly,
+		timeout:  time.Minute,
+	}, nil
+}
+
+func (s *HTTPStore) Get(key string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.keyURL(key), nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("task: %q not found in build cache: %w", key, fs.ErrNotExist)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("task: unable to read %q from build cache: %s", key, resp.Status)
+	}
+	return &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+func (s *HTTPStore) Put(key string, r io.Reader) error {
+	if s.readOnly {
+		return nil
+	}
+	// Contents are addressed by their digest, so there's no need to upload
+	// them again if the server already has them
+	if strings.HasPrefix(key, prefixContent) && s.has(key) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.keyURL(key), r)
+	if err != nil {
+		return err
+	}
+	if f, ok := r.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := f.Stat(); err == nil {
+			req.ContentLength = info.Size()
+		}
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("task: unable to write %q to build cache: %s", key, resp.Status)
+	}
+	return nil
+}
+
+// has returns true if the server answers a HEAD request for the given key
+// successfully.
+func (s *HTTPStore) has(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.keyURL(key), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+func (s *HTTPStore) keyURL(key string) string {
+	return s.url.JoinPath(key).String()
+}
+
+// cancelReadCloser cancels the context of a request once its body is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
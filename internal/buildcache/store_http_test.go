@@ -0,0 +1,166 @@
+package buildcache_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/buildcache"
+)
+
+// cacheServer is an in-memory build cache server.
+type cacheServer struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+	puts  int
+}
+
+func newCacheServer(t *testing.T) (*cacheServer, *httptest.Server) {
+	t.Helper()
+	s := &cacheServer{blobs: map[string][]byte{}}
+	srv := httptest.NewTLSServer(s)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func (s *cacheServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/cache/")
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		b, ok := s.blobs[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(b)
+	case http.MethodPut:
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.blobs[key] = b
+		s.puts++
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHTTPStore(t *testing.T) {
+	t.Parallel()
+
+	server, srv := newCacheServer(t)
+	remote, err := buildcache.NewHTTPStore(srv.URL+"/cache", srv.Client(), false, false)
+	require.NoError(t, err)
+
+	src := t.TempDir()
+	files := []string{filepath.Join(src, "a.txt"), filepath.Join(src, "b.txt")}
+	require.NoError(t, os.WriteFile(files[0], []byte("same"), 0o644))
+	require.NoError(t, os.WriteFile(files[1], []byte("same"), 0o644))
+
+	// Save from one machine...
+	cache := buildcache.New(buildcache.NewTieredStore(buildcache.NewDirStore(t.TempDir()), remote))
+	require.NoError(t, cache.Save(src, "key", files))
+	// Both files have the same contents, so they are only uploaded once
+	assert.Equal(t, 2, server.puts)
+
+	// ...and restore on another one
+	local := t.TempDir()
+	cache = buildcache.New(buildcache.NewTieredStore(buildcache.NewDirStore(local), remote))
+	dst := t.TempDir()
+	restored, err := cache.Restore(dst, "key")
+	require.NoError(t, err)
+	assert.True(t, restored)
+	b, err := os.ReadFile(filepath.Join(dst, "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "same", string(b))
+
+	// The blobs are now available locally
+	cache = buildcache.New(buildcache.NewDirStore(local))
+	restored, err = cache.Restore(t.TempDir(), "key")
+	require.NoError(t, err)
+	assert.True(t, restored)
+
+	restored, err = cache.Restore(dst, "missing")
+	require.NoError(t, err)
+	assert.False(t, restored)
+}
+
+func TestHTTPStoreReadOnly(t *testing.T) {
+	t.Parallel()
+
+	server, srv := newCacheServer(t)
+	remote, err := buildcache.NewHTTPStore(srv.URL+"/cache", srv.Client(), true, false)
+	require.NoError(t, err)
+
+	src := t.TempDir()
+	files := []string{filepath.Join(src, "a.txt")}
+	require.NoError(t, os.WriteFile(files[0], []byte("a"), 0o644))
+
+	cache := buildcache.New(buildcache.NewTieredStore(buildcache.NewDirStore(t.TempDir()), remote))
+	require.NoError(t, cache.Save(src, "key", files))
+	assert.Equal(t, 0, server.puts)
+}
+
+func TestHTTPStoreInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := buildcache.NewHTTPStore("ftp://example.com", nil, false, false)
+	require.Error(t, err)
+}
+
+func TestHTTPStoreInsecure(t *testing.T) {
+	t.Parallel()
+
+	_, err := buildcache.NewHTTPStore("http://example.com", nil, false, false)
+	var notSecure *errors.TaskfileNotSecureError
+	require.ErrorAs(t, err, &notSecure)
+
+	_, err = buildcache.NewHTTPStore("http://example.com", nil, false, true)
+	require.NoError(t, err)
+}
+
+func TestHTTPStoreCorruptedContents(t *testing.T) {
+	t.Parallel()
+
+	server, srv := newCacheServer(t)
+	remote, err := buildcache.NewHTTPStore(srv.URL+"/cache", srv.Client(), false, false)
+	require.NoError(t, err)
+
+	src := t.TempDir()
+	files := []string{filepath.Join(src, "a.txt")}
+	require.NoError(t, os.WriteFile(files[0], []byte("a"), 0o644))
+	require.NoError(t, buildcache.New(remote).Save(src, "key", files))
+	digest, err := buildcache.FileDigest(files[0])
+	require.NoError(t, err)
+	server.blobs["cas/"+digest] = []byte("poisoned")
+
+	local := t.TempDir()
+	cache := buildcache.New(buildcache.NewTieredStore(buildcache.NewDirStore(local), remote))
+	dst := t.TempDir()
+	restored, err := cache.Restore(dst, "key")
+	require.ErrorIs(t, err, buildcache.ErrDigestMismatch)
+	assert.False(t, restored)
+	assert.NoFileExists(t, filepath.Join(dst, "a.txt"))
+	assert.NoFileExists(t, filepath.Join(local, "cas", digest))
+
+	// A corrupted blob already in the local store is deleted
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "cas"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "cas", digest), []byte("poisoned"), 0o644))
+	restored, err = buildcache.New(buildcache.NewDirStore(local)).Restore(dst, "key")
+	require.ErrorIs(t, err, buildcache.ErrDigestMismatch)
+	assert.False(t, restored)
+	assert.NoFileExists(t, filepath.Join(local, "cas", digest))
+}
@@ -0,0 +1,81 @@
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// TieredStore is a [Store] that combines a local store with remote ones. Blobs
+// are read from the first store that has them, and blobs found in a remote
+// store are copied to the local one so that they are read locally next time.
+// Blobs are written to every store. The contents of the blobs read from a
+// remote store are checked against their digest before they are copied.
+type TieredStore struct {
+	local  Store
+	remote []Store
+}
+
+// NewTieredStore returns a store that reads from the local store first, and
+// then from each of the remote stores in order.
+func NewTieredStore(local Store, remote ...Store) *TieredStore {
+	return &TieredStore{local: local, remote: remote}
+}
+
+func (s *TieredStore) Get(key string) (io.ReadCloser, error) {
+	r, err := s.local.Get(key)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return r, err
+	}
+	for _, remote := range s.remote {
+		r, err := remote.Get(key)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		err = s.local.Put(key, io.TeeReader(r, h))
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		if digest, ok := strings.CutPrefix(key, prefixContent); ok && hex.EncodeToString(h.Sum(nil)) != digest {
+			_ = s.Delete(key)
+			return nil, fmt.Errorf("%w: %s", ErrDigestMismatch, digest)
+		}
+		return s.local.Get(key)
+	}
+	return nil, err
+}
+
+func (s *TieredStore) Put(key string, r io.Reader) error {
+	if err := s.local.Put(key, r); err != nil {
+		return err
+	}
+	var errs []error
+	for _, remote := range s.remote {
+		// The reader was consumed by the local store, so read it back from there
+		lr, err := s.local.Get(key)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, remote.Put(key, lr))
+		lr.Close()
+	}
+	return errors.Join(errs...)
+}
+
+// Delete deletes the blob with the given key from the local store. Remote
+// stores are shared, so they are left alone.
+func (s *TieredStore) Delete(key string) error {
+	if d, ok := s.local.(deleter); ok {
+		return d.Delete(key)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package editors
+
+import (
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+type (
+	// TaskDescription describes a compiled task, with everything it needs and
+	// does, for use in editor integrations and other tooling
+	TaskDescription struct {
+		Task
+		Dir           string         `json:"dir"`
+		Vars          []Var          `json:"vars"`
+		Env           []Var          `json:"env"`
+		Deps          []Dep          `json:"deps"`
+		Cmds          []Cmd          `json:"cmds"`
+		Sources       []string       `json:"sources"`
+		Generates     []string       `json:"generates"`
+		Requires      []Requirement  `json:"requires"`
+		Preconditions []Precondition `json:"preconditions"`
+		Platforms     []string       `json:"platforms"`
+	}
+	// Var describes a variable. Dynamic variables aren't evaluated, so they
+	// have the command that sets them instead of a value.
+	Var struct {
+		Name  string  `json:"name"`
+		Value any     `json:"value,omitempty"`
+		Sh    *string `json:"sh,omitempty"`
+	}
+	// Dep describes a dependency of a task
+	Dep struct {
+		Task   string `json:"task"`
+		Vars   []Var  `json:"vars,omitempty"`
+		Silent bool   `json:"silent,omitempty"`
+		For    bool   `json:"for,omitempty"`
+	}
+	// Cmd describes a command of a task, which either runs a shell command or
+	// calls another task
+	Cmd struct {
+		Cmd         string   `json:"cmd,omitempty"`
+		Task        string   `json:"task,omitempty"`
+		Vars        []Var    `json:"vars,omitempty"`
+		If          string   `json:"if,omitempty"`
+		Silent      bool     `json:"silent,omitempty"`
+		IgnoreError bool     `json:"ignore_error,omitempty"`
+		Defer       bool     `json:"defer,omitempty"`
+		For         bool     `json:"for,omitempty"`
+		Platforms   []string `json:"platforms,omitempty"`
+	}
+	// Requirement describes a variable required by a task, and the values
+	// allowed for it, if any
+	Requirement struct {
+		Name    string   `json:"name"`
+		Enum    []string `json:"enum,omitempty"`
+		Type    string   `json:"type,omitempty"`
+		Pattern string   `json:"pattern,omitempty"`
+		Min     string   `json:"min,omitempty"`
+		Max     string   `json:"max,omitempty"`
+		Default *string  `json:"default,omitempty"`
+	}
+	// Precondition describes a precondition of a task
+	Precondition struct {
+		Sh  string `json:"sh"`
+		Msg string `json:"msg,omitempty"`
+	}
+)
+
+// NewTaskDescription describes a compiled task. The commands and dependencies
+// that loop are described once, with For set, since their items are only known
+// when the task runs.
+func NewTaskDescription(task *ast.Task) TaskDescription {
+	d := TaskDescription{
+		Task:          NewTask(task),
+		Dir:           task.Dir,
+		Vars:          newVars(task.Vars),
+		Env:           newVars(task.Env),
+		Deps:          []Dep{},
+		Cmds:          []Cmd{},
+		Sources:       newGlobs(task.Sources),
+		Generates:     newGlobs(task.Generates),
+		Requires:      []Requirement{},
+		Preconditions: []Precondition{},
+		Platforms:     newPlatforms(task.Platforms),
+	}
+	for _, dep := range task.Deps {
+		d.Deps = append(d.Deps, Dep{
+			Task:   dep.Task,
+			Vars:   newVars(dep.Vars),
+			Silent: dep.Silent,
+			For:    dep.For != nil,
+		})
+	}
+	for _, cmd := range task.Cmds {
+		c := Cmd{
+			Cmd:         cmd.Cmd,
+			Task:        cmd.Task,
+			Vars:        newVars(cmd.Vars),
+			If:          cmd.If,
+			Silent:      cmd.Silent,
+			IgnoreError: cmd.IgnoreError,
+			Defer:       cmd.Defer,
+			For:         cmd.For != nil,
+		}
+		if len(cmd.Platforms) > 0 {
+			c.Platforms = newPlatforms(cmd.Platforms)
+		}
+		d.Cmds = append(d.Cmds, c)
+	}
+	if task.Requires != nil {
+		for _, v := range task.Requires.Vars {
+			d.Requires = append(d.Requires, Requirement{
+				Name:    v.Name,
+				Enum:    v.Enum,
+				Type:    v.Type,
+				Pattern: v.Pattern,
+				Min:     v.Min,
+				Max:     v.Max,
+				Default: v.Default,
+			})
+		}
+	}
+	for _, p := range task.Preconditions {
+		d.Preconditions = append(d.Preconditions, Precondition{Sh: p.Sh, Msg: p.Msg})
+	}
+	return d
+}
+
+func newVars(vars *ast.Vars) []Var {
+	list := []Var{}
+	for name, v := range vars.All() {
+		if v.Sh != nil {
+			list = append(list, Var{Name: name, Sh: v.Sh})
+			continue
+		}
+		list = append(list, Var{Name: name, Value: v.Value})
+	}
+	return list
+}
+
+// newGlobs returns the patterns of globs, with a leading "!" for the ones that
+// exclude files
+func newGlobs(globs []*ast.Glob) []string {
+	list := []string{}
+	for _, g := range globs {
+		if g.Negate {
+			list = append(list, "!"+g.Glob)
+		} else {
+			list = append(list, g.Glob)
+		}
+	}
+	return list
+}
+
+func newPlatforms(platforms []*ast.Platform) []string {
+	list := []string{}
+	for _, p := range platforms {
+		switch {
+		case p.OS != "" && p.Arch != "":
+			list = append(list, p.OS+"/"+p.Arch)
+		case p.OS != "":
+			list = append(list, p.OS)
+		default:
+			list = append(list, p.Arch)
+		}
+	}
+	return list
+}
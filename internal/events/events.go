@@ -0,0 +1,113 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Type identifies the kind of lifecycle [Event] that occurred during a run.
+type Type string
+
+const (
+	TaskStarted        Type = "task_started"
+	TaskSkipped        Type = "task_skipped"
+	TaskUpToDate       Type = "task_up_to_date"
+	TaskFinished       Type = "task_finished"
+	PreconditionFailed Type = "precondition_failed"
+	CommandStarted     Type = "command_started"
+	CommandFinished    Type = "command_finished"
+	DeferredRan        Type = "deferred_ran"
+	ConcurrencyWaited  Type = "concurrency_waited"
+)
+
+// Reasons given for a [TaskSkipped] event.
+const (
+	ReasonPlatform  = "platform"
+	ReasonIf        = "if"
+	ReasonDuplicate = "duplicate"
+)
+
+// ReasonCache is given for a [TaskUpToDate] event when the outputs of the task
+// were restored from the build cache instead of running it.
+const ReasonCache = "cache"
+
+// Event is a single lifecycle event emitted while running tasks.
+type Event struct {
+	Type     Type
+	Time     time.Time
+	Task     string
+	Command  string
+	Reason   string
+	ExitCode *int
+	// Duration is set on events that are emitted when something finishes,
+	// in which case Time is the moment it finished.
+	Duration time.Duration
+	Err      error
+	// Lane identifies the goroutine that emitted the event. Events of tasks
+	// that run in parallel are emitted from different lanes, while tasks that
+	// run one after the other share the same lane.
+	Lane int
+	// Output holds the end of the output of a failed command. It is only set
+	// on [CommandFinished] events when a listener is an [OutputCapturer].
+	Output string
+}
+
+// A Listener receives the events emitted during a run. Events may be emitted
+// from multiple goroutines at the same time, so implementations must be safe
+// for concurrent use.
+type Listener interface {
+	Handle(Event)
+}
+
+// An OutputCapturer is a [Listener] that needs the output of failed commands.
+// When one is registered, the output of commands is captured and attached to
+// [CommandFinished] events of commands that fail.
+type OutputCapturer interface {
+	Listener
+	CapturesOutput() bool
+}
+
+// ListenerFunc is an adapter to allow the use of ordinary functions as
+// [Listener]s.
+type ListenerFunc func(Event)
+
+func (f ListenerFunc) Handle(ev Event) {
+	f(ev)
+}
+
+// MarshalJSON encodes the event as a flat JSON object. Durations are given in
+// milliseconds and errors as their message.
+func (ev Event) MarshalJSON() ([]byte, error) {
+	var durationMs *float64
+	if ev.Type == TaskFinished || ev.Type == CommandFinished || ev.Type == DeferredRan || ev.Type == ConcurrencyWaited {
+		ms := float64(ev.Duration) / float64(time.Millisecond)
+		durationMs = &ms
+	}
+	var errMsg string
+	if ev.Err != nil {
+		errMsg = ev.Err.Error()
+	}
+	return json.Marshal(struct {
+		Type       Type      `json:"type"`
+		Time       time.Time `json:"time"`
+		Task       string    `json:"task"`
+		Command    string    `json:"command,omitempty"`
+		Reason     string    `json:"reason,omitempty"`
+		ExitCode   *int      `json:"exit_code,omitempty"`
+		DurationMs *float64  `json:"duration_ms,omitempty"`
+		Error      string    `json:"error,omitempty"`
+		Output     string    `json:"output,omitempty"`
+		Lane       int       `json:"lane,omitempty"`
+	}{
+		Type:       ev.Type,
+		Time:       ev.Time,
+		Task:       ev.Task,
+		Command:    ev.Command,
+		Reason:     ev.Reason,
+		ExitCode:   ev.ExitCode,
+		DurationMs: durationMs,
+		Error:      errMsg,
+		Output:     ev.Output,
+		Lane:       ev.Lane,
+	})
+}
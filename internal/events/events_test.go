@@ -0,0 +1,38 @@
+package events_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-task/task/v3/internal/events"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	listener := events.NewJSON(&buff)
+
+	exitCode := 2
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	listener.Handle(events.Event{Type: events.TaskStarted, Time: ts, Task: "build"})
+	listener.Handle(events.Event{
+		Type:     events.CommandFinished,
+		Time:     ts,
+		Task:     "build",
+		Command:  "go build",
+		ExitCode: &exitCode,
+		Duration: 1500 * time.Microsecond,
+		Err:      errors.New("exit status 2"),
+	})
+
+	assert.Equal(t,
+		`{"type":"task_started","time":"2026-01-02T03:04:05Z","task":"build"}`+"\n"+
+			`{"type":"command_finished","time":"2026-01-02T03:04:05Z","task":"build","command":"go build","exit_code":2,"duration_ms":1.5,"error":"exit status 2"}`+"\n",
+		buff.String(),
+	)
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON is a [Listener] that writes every event it receives as a single line of
+// JSON to the underlying writer.
+type JSON struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSON creates a new [JSON] listener that writes to w.
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{enc: json.NewEncoder(w)}
+}
+
+func (j *JSON) Handle(ev Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = j.enc.Encode(ev)
+}
+
+// OpenFile opens the destination of an event stream. The name is either a path
+// to a file, which is created or truncated, or an already open file descriptor
+// in the form "fd:N".
+func OpenFile(name string) (io.WriteCloser, error) {
+	if fd, ok := strings.CutPrefix(name, "fd:"); ok {
+		n, err := strconv.ParseUint(fd, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("task: invalid file descriptor %q", name)
+		}
+		f := os.NewFile(uintptr(n), name)
+		if f == nil {
+			return nil, fmt.Errorf("task: invalid file descriptor %q", name)
+		}
+		return f, nil
+	}
+	return os.Create(name)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"mvdan.cc/sh/moreinterp/coreutils"
 	"mvdan.cc/sh/v3/expand"
@@ -29,9 +30,15 @@ type RunCommandOptions struct {
 	Stdin     io.Reader
 	Stdout    io.Writer
 	Stderr    io.Writer
+	// KillTimeout is the grace period between the interrupt signal sent to a
+	// running program when the context is done and the kill signal that
+	// follows it. If zero, the interpreter's default is used.
+	KillTimeout time.Duration
 }
 
-// RunCommand runs a shell command
+// RunCommand runs a shell command. When the given context is done, any running
+// program is interrupted first and killed once [RunCommandOptions.KillTimeout]
+// has elapsed.
 func RunCommand(ctx context.Context, opts *RunCommandOptions) error {
 	if opts == nil {
 		return ErrNilOptions
@@ -59,7 +66,7 @@ func RunCommand(ctx context.Context, opts *RunCommandOptions) error {
 	r, err := interp.New(
 		interp.Params(params...),
 		interp.Env(expand.ListEnviron(environ...)),
-		interp.ExecHandlers(execHandlers()...),
+		interp.ExecHandlers(execHandlers(opts.KillTimeout)...),
 		interp.OpenHandler(openHandler),
 		interp.StdIO(opts.Stdin, opts.Stdout, opts.Stderr),
 		dirOption(opts.Dir),
@@ -139,10 +146,16 @@ func ExpandFields(s string) ([]string, error) {
 	return expand.Fields(cfg, words...)
 }
 
-func execHandlers() (handlers []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc) {
+func execHandlers(killTimeout time.Duration) (handlers []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc) {
 	if useGoCoreUtils {
 		handlers = append(handlers, coreutils.ExecHandler)
 	}
+	if killTimeout != 0 {
+		// Replace the default handler so the grace period can be customized
+		handlers = append(handlers, func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+			return interp.DefaultExecHandler(killTimeout)
+		})
+	}
 	return handlers
 }
 
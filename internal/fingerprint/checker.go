@@ -13,8 +13,11 @@ type StatusCheckable interface {
 
 // SourcesCheckable defines any type that can check if the sources of a task are up-to-date.
 type SourcesCheckable interface {
-	IsUpToDate(t *ast.Task) (bool, error)
-	Value(t *ast.Task) (any, error)
+	IsUpToDate(ctx context.Context, t *ast.Task) (bool, error)
+	Value(ctx context.Context, t *ast.Task) (any, error)
 	OnError(t *ast.Task) error
 	Kind() string
+	// NeedsSources returns false if the checker also checks the tasks that
+	// have a fingerprint command but no sources.
+	NeedsSources() bool
 }
@@ -121,8 +121,8 @@ func (_m *MockSourcesCheckable) EXPECT() *MockSourcesCheckable_Expecter {
 }
 
 // IsUpToDate provides a mock function for the type MockSourcesCheckable
-func (_mock *MockSourcesCheckable) IsUpToDate(t *ast.Task) (bool, error) {
-	ret := _mock.Called(t)
+func (_mock *MockSourcesCheckable) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
+	ret := _mock.Called(ctx, t)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IsUpToDate")
@@ -130,16 +130,16 @@ func (_mock *MockSourcesCheckable) IsUpToDate(t *ast.Task) (bool, error) {
 
 	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*ast.Task) (bool, error)); ok {
-		return returnFunc(t)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ast.Task) (bool, error)); ok {
+		return returnFunc(ctx, t)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*ast.Task) bool); ok {
-		r0 = returnFunc(t)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ast.Task) bool); ok {
+		r0 = returnFunc(ctx, t)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*ast.Task) error); ok {
-		r1 = returnFunc(t)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *ast.Task) error); ok {
+		r1 = returnFunc(ctx, t)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,14 +152,15 @@ type MockSourcesCheckable_IsUpToDate_Call struct {
 }
 
 // IsUpToDate is a helper method to define mock.On call
+//   - ctx
 //   - t
-func (_e *MockSourcesCheckable_Expecter) IsUpToDate(t interface{}) *MockSourcesCheckable_IsUpToDate_Call {
-	return &MockSourcesCheckable_IsUpToDate_Call{Call: _e.mock.On("IsUpToDate", t)}
+func (_e *MockSourcesCheckable_Expecter) IsUpToDate(ctx interface{}, t interface{}) *MockSourcesCheckable_IsUpToDate_Call {
+	return &MockSourcesCheckable_IsUpToDate_Call{Call: _e.mock.On("IsUpToDate", ctx, t)}
 }
 
-func (_c *MockSourcesCheckable_IsUpToDate_Call) Run(run func(t *ast.Task)) *MockSourcesCheckable_IsUpToDate_Call {
+func (_c *MockSourcesCheckable_IsUpToDate_Call) Run(run func(ctx context.Context, t *ast.Task)) *MockSourcesCheckable_IsUpToDate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*ast.Task))
+		run(args[0].(context.Context), args[1].(*ast.Task))
 	})
 	return _c
 }
@@ -169,7 +170,7 @@ func (_c *MockSourcesCheckable_IsUpToDate_Call) Return(b bool, err error) *MockS
 	return _c
 }
 
-func (_c *MockSourcesCheckable_IsUpToDate_Call) RunAndReturn(run func(t *ast.Task) (bool, error)) *MockSourcesCheckable_IsUpToDate_Call {
+func (_c *MockSourcesCheckable_IsUpToDate_Call) RunAndReturn(run func(ctx context.Context, t *ast.Task) (bool, error)) *MockSourcesCheckable_IsUpToDate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -218,6 +219,50 @@ func (_c *MockSourcesCheckable_Kind_Call) RunAndReturn(run func() string) *MockS
 	return _c
 }
 
+// NeedsSources provides a mock function for the type MockSourcesCheckable
+func (_mock *MockSourcesCheckable) NeedsSources() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsSources")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockSourcesCheckable_NeedsSources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsSources'
+type MockSourcesCheckable_NeedsSources_Call struct {
+	*mock.Call
+}
+
+// NeedsSources is a helper method to define mock.On call
+func (_e *MockSourcesCheckable_Expecter) NeedsSources() *MockSourcesCheckable_NeedsSources_Call {
+	return &MockSourcesCheckable_NeedsSources_Call{Call: _e.mock.On("NeedsSources")}
+}
+
+func (_c *MockSourcesCheckable_NeedsSources_Call) Run(run func()) *MockSourcesCheckable_NeedsSources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSourcesCheckable_NeedsSources_Call) Return(b bool) *MockSourcesCheckable_NeedsSources_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockSourcesCheckable_NeedsSources_Call) RunAndReturn(run func() bool) *MockSourcesCheckable_NeedsSources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OnError provides a mock function for the type MockSourcesCheckable
 func (_mock *MockSourcesCheckable) OnError(t *ast.Task) error {
 	ret := _mock.Called(t)
@@ -264,8 +309,8 @@ func (_c *MockSourcesCheckable_OnError_Call) RunAndReturn(run func(t *ast.Task)
 }
 
 // Value provides a mock function for the type MockSourcesCheckable
-func (_mock *MockSourcesCheckable) Value(t *ast.Task) (any, error) {
-	ret := _mock.Called(t)
+func (_mock *MockSourcesCheckable) Value(ctx context.Context, t *ast.Task) (any, error) {
+	ret := _mock.Called(ctx, t)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Value")
@@ -273,18 +318,18 @@ func (_mock *MockSourcesCheckable) Value(t *ast.Task) (any, error) {
 
 	var r0 any
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*ast.Task) (any, error)); ok {
-		return returnFunc(t)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ast.Task) (any, error)); ok {
+		return returnFunc(ctx, t)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*ast.Task) any); ok {
-		r0 = returnFunc(t)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ast.Task) any); ok {
+		r0 = returnFunc(ctx, t)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(any)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*ast.Task) error); ok {
-		r1 = returnFunc(t)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *ast.Task) error); ok {
+		r1 = returnFunc(ctx, t)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -297,14 +342,15 @@ type MockSourcesCheckable_Value_Call struct {
 }
 
 // Value is a helper method to define mock.On call
+//   - ctx
 //   - t
-func (_e *MockSourcesCheckable_Expecter) Value(t interface{}) *MockSourcesCheckable_Value_Call {
-	return &MockSourcesCheckable_Value_Call{Call: _e.mock.On("Value", t)}
+func (_e *MockSourcesCheckable_Expecter) Value(ctx interface{}, t interface{}) *MockSourcesCheckable_Value_Call {
+	return &MockSourcesCheckable_Value_Call{Call: _e.mock.On("Value", ctx, t)}
 }
 
-func (_c *MockSourcesCheckable_Value_Call) Run(run func(t *ast.Task)) *MockSourcesCheckable_Value_Call {
+func (_c *MockSourcesCheckable_Value_Call) Run(run func(ctx context.Context, t *ast.Task)) *MockSourcesCheckable_Value_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*ast.Task))
+		run(args[0].(context.Context), args[1].(*ast.Task))
 	})
 	return _c
 }
@@ -314,7 +360,7 @@ func (_c *MockSourcesCheckable_Value_Call) Return(v any, err error) *MockSources
 	return _c
 }
 
-func (_c *MockSourcesCheckable_Value_Call) RunAndReturn(run func(t *ast.Task) (any, error)) *MockSourcesCheckable_Value_Call {
+func (_c *MockSourcesCheckable_Value_Call) RunAndReturn(run func(ctx context.Context, t *ast.Task) (any, error)) *MockSourcesCheckable_Value_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,290 @@
+package fingerprint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/internal/execext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Kinds of change of a source file
+const (
+	SourceAdded    = "added"
+	SourceRemoved  = "removed"
+	SourceModified = "modified"
+)
+
+type (
+	// Explanation tells why a task is or isn't up-to-date.
+	Explanation struct {
+		UpToDate bool
+		// Method is the kind of the sources checker, or empty if the task has
+		// no sources
+		Method  string
+		Sources *SourcesExplanation
+		Status  []StatusResult
+	}
+	// SourcesExplanation tells why the sources of a task are or aren't
+	// up-to-date.
+	SourcesExplanation struct {
+		UpToDate bool
+		// NeverRan is true if there's no fingerprint of a previous run of the
+		// task
+		NeverRan bool
+		// DefinitionChanged is true if the commands, dependencies, status,
+		// sources or generates of the task changed in the Taskfile since the
+		// last run. It doesn't make the task run again, so it is only known
+		// when the definition is given with [WithDefinition].
+		DefinitionChanged bool
+		// Changed lists the source files that changed since the last run
+		Changed []SourceChange
+		// MissingGenerates lists the generates globs that match no files
+		MissingGenerates []string
+		// Reason describes the change when it can't be told by files, e.g.
+		// when the fingerprint of the previous run has no list of files
+		Reason string
+	}
+	// SourceChange is a source file that changed since the last run of a task.
+	SourceChange struct {
+		// Path is relative to the directory of the task
+		Path   string
+		Change string
+	}
+	// StatusResult is the result of a status command of a task.
+	StatusResult struct {
+		Cmd    string
+		Err    error
+		Output string
+	}
+)
+
+// SourcesExplainable is implemented by the [SourcesCheckable] that can tell why
+// the sources of a task are or aren't up-to-date. An explanation must never
+// change the stored fingerprint of the task.
+type SourcesExplainable interface {
+	Explain(ctx context.Context, t *ast.Task) (*SourcesExplanation, error)
+}
+
+// ExplainTask tells why the given task is or isn't up-to-date. It uses the same
+// options as [IsTaskUpToDate], but never changes the stored fingerprints.
+func ExplainTask(
+	ctx context.Context,
+	t *ast.Task,
+	opts ...CheckerOption,
+) (*Explanation, error) {
+	config := &CheckerConfig{method: "none"}
+	for _, opt := range opts {
+		opt(config)
+	}
+	config.dry = true
+
+	var err error
+	if config.sourcesChecker == nil {
+		config.sourcesChecker, err = NewSourcesChecker(config.method, config.tempDir, config.dry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	explanation := &Explanation{}
+	statusIsSet := len(t.Status) != 0
+	sourcesIsSet := len(t.Sources) != 0 || (t.Fingerprint != "" && !config.sourcesChecker.NeedsSources())
+
+	statusUpToDate := true
+	for _, s := range t.Status {
+		var output bytes.Buffer
+		err := execext.RunCommand(ctx, &execext.RunCommandOptions{
+			Command: s,
+			Dir:     t.Dir,
+			Env:     env.Get(t),
+			Stdout:  &output,
+			Stderr:  &output,
+		})
+		if err != nil {
+			statusUpToDate = false
+		}
+		explanation.Status = append(explanation.Status, StatusResult{
+			Cmd:    s,
+			Err:    err,
+			Output: strings.TrimSpace(output.String()),
+		})
+	}
+
+	if sourcesIsSet {
+		explanation.Method = config.sourcesChecker.Kind()
+		if explainer, ok := config.sourcesChecker.(SourcesExplainable); ok {
+			explanation.Sources, err = explainer.Explain(ctx, t)
+		} else {
+			explanation.Sources = &SourcesExplanation{}
+			explanation.Sources.UpToDate, err = config.sourcesChecker.IsUpToDate(ctx, t)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if config.definition != nil {
+			explanation.Sources.DefinitionChanged = definitionChanged(config.tempDir, t, config.definition)
+		}
+	}
+
+	switch {
+	case statusIsSet && sourcesIsSet:
+		explanation.UpToDate = statusUpToDate && explanation.Sources.UpToDate
+	case statusIsSet:
+		explanation.UpToDate = statusUpToDate
+	case sourcesIsSet:
+		explanation.UpToDate = explanation.Sources.UpToDate
+	}
+	return explanation, nil
+}
+
+// missingGenerates returns the generates globs of the task that match no files.
+func missingGenerates(t *ast.Task) []string {
+	var missing []string
+	for _, g := range t.Generates {
+		if g.Negate {
+			continue
+		}
+		if files, err := glob(t.Dir, g.Glob); err != nil || len(files) == 0 {
+			missing = append(missing, g.Glob)
+		}
+	}
+	return missing
+}
+
+// A sourceList maps the paths of the source files of a task, relative to its
+// directory, to the hashes of their contents, along with the hashes of its
+// tracked inputs. It is stored next to the fingerprint of the task, which is a
+// single hash of all of them, to tell what changed. It is removed along with
+// the fingerprint.
+type sourceList map[string]string
+
+// sourceListSuffix is appended to the path of the fingerprint of a task to get
+// the path of its source list
+const sourceListSuffix = ".files"
+
+func sourceListPath(fingerprintFile string) string {
+	return fingerprintFile + sourceListSuffix
+}
+
+func readSourceList(path string) (sourceList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	list := sourceList{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Lines look like "<hash>  <path>", like the output of sha256sum
+		hash, path, ok := strings.Cut(scanner.Text(), "  ")
+		if ok {
+			list[path] = hash
+		}
+	}
+	return list, scanner.Err()
+}
+
+func writeSourceList(path string, list sourceList) error {
+	var b bytes.Buffer
+	for _, name := range slices.Sorted(maps.Keys(list)) {
+		fmt.Fprintf(&b, "%s  %s\n", list[name], name)
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// relSourcePath returns the path of a source file relative to the directory of
+// its task, which is how it is stored in a [sourceList].
+func relSourcePath(t *ast.Task, path string) string {
+	if rel, err := filepath.Rel(t.Dir, path); err == nil {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+// changes returns the files that were added, removed or modified in list
+// compared to old, sorted by path.
+func (list sourceList) changes(old sourceList) []SourceChange {
+	var changes []SourceChange
+	for path, hash := range list {
+		oldHash, ok := old[path]
+		switch {
+		case !ok:
+			changes = append(changes, SourceChange{Path: path, Change: SourceAdded})
+		case oldHash != hash:
+			changes = append(changes, SourceChange{Path: path, Change: SourceModified})
+		}
+	}
+	for path := range old {
+		if _, ok := list[path]; !ok {
+			changes = append(changes, SourceChange{Path: path, Change: SourceRemoved})
+		}
+	}
+	slices.SortFunc(changes, func(a, b SourceChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes
+}
+
+// explainSourceList explains the sources of a task that is fingerprinted by
+// the hashes of its files.
+func explainSourceList(t *ast.Task, fingerprintFile, newHash string, list sourceList) *SourcesExplanation {
+	explanation := &SourcesExplanation{MissingGenerates: missingGenerates(t)}
+	data, err := os.ReadFile(fingerprintFile)
+	if err != nil {
+		explanation.NeverRan = true
+		return explanation
+	}
+	if strings.TrimSpace(string(data)) != newHash {
+		old, err := readSourceList(sourceListPath(fingerprintFile))
+		if err == nil {
+			explanation.Changed = list.changes(old)
+		}
+		if len(explanation.Changed) == 0 {
+			explanation.Reason = "the fingerprint of the sources changed"
+		}
+	}
+	explanation.UpToDate = len(explanation.Changed) == 0 && explanation.Reason == "" && len(explanation.MissingGenerates) == 0
+	return explanation
+}
+
+// definitionFilePath returns the path of the file that holds the hash of the
+// definition of the given task in its last run
+func definitionFilePath(tempDir string, t *ast.Task) string {
+	return filepath.Join(tempDir, "definition", normalizeFilename(t.Name()))
+}
+
+// storeDefinition stores the hash of the definition of the given task, before
+// it runs, so that [ExplainTask] can tell when it changed.
+func storeDefinition(tempDir string, t, definition *ast.Task) error {
+	path := definitionFilePath(tempDir, t)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(definitionHash(definition)+"\n"), 0o644)
+}
+
+// definitionStored tells if a hash of the definition of the given task was
+// stored.
+func definitionStored(tempDir string, t *ast.Task) bool {
+	_, err := os.Stat(definitionFilePath(tempDir, t))
+	return err == nil
+}
+
+// definitionChanged tells if the definition of the given task changed since
+// the hash stored by its last run. It is false when no hash was stored.
+func definitionChanged(tempDir string, t, definition *ast.Task) bool {
+	data, err := os.ReadFile(definitionFilePath(tempDir, t))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) != definitionHash(definition)
+}
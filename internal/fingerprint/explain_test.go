@@ -0,0 +1,113 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestExplainTask(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []string{"checksum", "git", "timestamp"} {
+		t.Run(method, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			tempDir := filepath.Join(dir, ".task")
+			write := func(name, contents string) {
+				t.Helper()
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644))
+			}
+			write("a.txt", "a")
+			write("b.txt", "b")
+
+			task := &ast.Task{
+				Task:      "build",
+				Dir:       dir,
+				Sources:   []*ast.Glob{{Glob: "*.txt"}},
+				Generates: []*ast.Glob{{Glob: "out"}},
+				Status:    []string{"echo checked && test -f out"},
+			}
+			explain := func() *Explanation {
+				t.Helper()
+				explanation, err := ExplainTask(t.Context(), task, WithMethod(method), WithTempDir(tempDir), WithDefinition(task))
+				require.NoError(t, err)
+				return explanation
+			}
+
+			explanation := explain()
+			assert.False(t, explanation.UpToDate)
+			assert.Equal(t, method, explanation.Method)
+			assert.True(t, explanation.Sources.NeverRan)
+			require.Len(t, explanation.Status, 1)
+			require.Error(t, explanation.Status[0].Err)
+			assert.Equal(t, "checked", explanation.Status[0].Output)
+
+			// Explaining never stores a fingerprint
+			assert.NoDirExists(t, tempDir)
+
+			// Run the task
+			write("out", "")
+			isUpToDate := func() bool {
+				t.Helper()
+				upToDate, err := IsTaskUpToDate(t.Context(), task,
+					WithMethod(method),
+					WithTempDir(tempDir),
+					WithDefinition(task),
+					WithLogger(&logger.Logger{}),
+				)
+				require.NoError(t, err)
+				return upToDate
+			}
+			isUpToDate()
+
+			explanation = explain()
+			assert.True(t, explanation.UpToDate)
+			assert.True(t, explanation.Sources.UpToDate)
+			assert.Empty(t, explanation.Sources.Changed)
+			require.NoError(t, explanation.Status[0].Err)
+
+			// Changing the commands changes the definition of the task,
+			// but it is only reported and doesn't make the task run again
+			task.Cmds = []*ast.Cmd{{Cmd: "touch out"}}
+			explanation = explain()
+			assert.True(t, explanation.UpToDate)
+			assert.True(t, explanation.Sources.DefinitionChanged)
+			assert.Empty(t, explanation.Sources.Changed)
+			assert.True(t, isUpToDate())
+
+			later := time.Now().Add(time.Hour)
+			write("a.txt", "changed")
+			require.NoError(t, os.Chtimes(filepath.Join(dir, "a.txt"), later, later))
+			explanation = explain()
+			assert.False(t, explanation.UpToDate)
+			assert.Equal(t, []SourceChange{{Path: "a.txt", Change: SourceModified}}, explanation.Sources.Changed)
+		})
+	}
+}
+
+func TestSourceListChanges(t *testing.T) {
+	t.Parallel()
+
+	old := sourceList{"a.txt": "1", "b.txt": "2", "c.txt": "3"}
+	list := sourceList{"a.txt": "1", "c.txt": "4", "d.txt": "5"}
+	assert.Equal(t, []SourceChange{
+		{Path: "b.txt", Change: SourceRemoved},
+		{Path: "c.txt", Change: SourceModified},
+		{Path: "d.txt", Change: SourceAdded},
+	}, list.changes(old))
+
+	path := filepath.Join(t.TempDir(), "build.files")
+	require.NoError(t, writeSourceList(path, list))
+	read, err := readSourceList(path)
+	require.NoError(t, err)
+	assert.Equal(t, list, read)
+}
@@ -1,16 +1,61 @@
 package fingerprint
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
+
+// SourcesCheckerFactory returns a new [SourcesCheckable] that keeps its state
+// in the given temporary directory. If dry is true, the checker must not write
+// anything.
+type SourcesCheckerFactory func(tempDir string, dry bool) SourcesCheckable
+
+var (
+	sourcesCheckersMu sync.RWMutex
+	sourcesCheckers   = map[string]SourcesCheckerFactory{
+		"timestamp": func(tempDir string, dry bool) SourcesCheckable {
+			return NewTimestampChecker(tempDir, dry)
+		},
+		"checksum": func(tempDir string, dry bool) SourcesCheckable {
+			return NewChecksumChecker(tempDir, dry)
+		},
+		"git": func(tempDir string, dry bool) SourcesCheckable {
+			return NewGitChecker(tempDir, dry)
+		},
+		"exec": func(tempDir string, dry bool) SourcesCheckable {
+			return NewExecChecker(tempDir, dry)
+		},
+		"none": func(string, bool) SourcesCheckable {
+			return NoneChecker{}
+		},
+	}
+)
+
+// RegisterSourcesChecker makes the checkers returned by the given factory
+// available as the given method. It returns an error if the method is already
+// registered.
+func RegisterSourcesChecker(method string, factory SourcesCheckerFactory) error {
+	if method == "" {
+		return fmt.Errorf("task: method name cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf(`task: nil factory for method "%s"`, method)
+	}
+	sourcesCheckersMu.Lock()
+	defer sourcesCheckersMu.Unlock()
+	if _, ok := sourcesCheckers[method]; ok {
+		return fmt.Errorf(`task: method "%s" is already registered`, method)
+	}
+	sourcesCheckers[method] = factory
+	return nil
+}
 
 func NewSourcesChecker(method, tempDir string, dry bool) (SourcesCheckable, error) {
-	switch method {
-	case "timestamp":
-		return NewTimestampChecker(tempDir, dry), nil
-	case "checksum":
-		return NewChecksumChecker(tempDir, dry), nil
-	case "none":
-		return NoneChecker{}, nil
-	default:
+	sourcesCheckersMu.RLock()
+	factory, ok := sourcesCheckers[method]
+	sourcesCheckersMu.RUnlock()
+	if !ok {
 		return nil, fmt.Errorf(`task: invalid method "%s"`, method)
 	}
+	return factory(tempDir, dry), nil
 }
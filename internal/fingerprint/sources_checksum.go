@@ -1,6 +1,7 @@
 package fingerprint
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -28,7 +29,7 @@ func NewChecksumChecker(tempDir string, dry bool) *ChecksumChecker {
 	}
 }
 
-func (checker *ChecksumChecker) IsUpToDate(t *ast.Task) (bool, error) {
+func (checker *ChecksumChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
 	if len(t.Sources) == 0 {
 		return false, nil
 	}
@@ -38,7 +39,7 @@ func (checker *ChecksumChecker) IsUpToDate(t *ast.Task) (bool, error) {
 	data, _ := os.ReadFile(checksumFile)
 	oldHash := strings.TrimSpace(string(data))
 
-	newHash, err := checker.checksum(t)
+	newHash, list, err := checker.checksum(t)
 	if err != nil {
 		return false, nil
 	}
@@ -48,38 +49,59 @@ func (checker *ChecksumChecker) IsUpToDate(t *ast.Task) (bool, error) {
 		if err = os.WriteFile(checksumFile, []byte(newHash+"\n"), 0o644); err != nil {
 			return false, err
 		}
+		if err = writeSourceList(sourceListPath(checksumFile), list); err != nil {
+			return false, err
+		}
 	}
 
-	if len(t.Generates) > 0 {
-		// For each specified 'generates' field, check whether the files actually exist
-		for _, g := range t.Generates {
-			if g.Negate {
-				continue
-			}
-			generates, err := glob(t.Dir, g.Glob)
-			if os.IsNotExist(err) {
-				return false, nil
-			}
-			if err != nil {
-				return false, err
-			}
-			if len(generates) == 0 {
-				return false, nil
-			}
-		}
+	if ok, err := generatesExist(t); !ok || err != nil {
+		return false, err
 	}
 
 	return oldHash == newHash, nil
 }
 
-func (checker *ChecksumChecker) Value(t *ast.Task) (any, error) {
-	return checker.checksum(t)
+// Explain implements the SourcesExplainable interface
+func (checker *ChecksumChecker) Explain(ctx context.Context, t *ast.Task) (*SourcesExplanation, error) {
+	newHash, list, err := checker.checksum(t)
+	if err != nil {
+		return nil, err
+	}
+	return explainSourceList(t, checker.checksumFilePath(t), newHash, list), nil
+}
+
+// generatesExist returns true if each of the generates globs of the task
+// matches at least one file
+func generatesExist(t *ast.Task) (bool, error) {
+	// For each specified 'generates' field, check whether the files actually exist
+	for _, g := range t.Generates {
+		if g.Negate {
+			continue
+		}
+		generates, err := glob(t.Dir, g.Glob)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if len(generates) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (checker *ChecksumChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	hash, _, err := checker.checksum(t)
+	return hash, err
 }
 
 func (checker *ChecksumChecker) OnError(t *ast.Task) error {
 	if len(t.Sources) == 0 {
 		return nil
 	}
+	_ = os.Remove(sourceListPath(checker.checksumFilePath(t)))
 	return os.Remove(checker.checksumFilePath(t))
 }
 
@@ -87,31 +109,45 @@ func (*ChecksumChecker) Kind() string {
 	return "checksum"
 }
 
-func (c *ChecksumChecker) checksum(t *ast.Task) (string, error) {
+func (*ChecksumChecker) NeedsSources() bool {
+	return true
+}
+
+// checksum returns the checksum of the sources and the tracked inputs of the
+// task, along with the checksum of each of them
+func (c *ChecksumChecker) checksum(t *ast.Task) (string, sourceList, error) {
 	sources, err := Globs(t.Dir, t.Sources)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	h := xxh3.New()
+	list := make(sourceList, len(sources))
 	buf := make([]byte, 128*1024)
-	for _, f := range sources {
+	for _, path := range sources {
 		// also sum the filename, so checksum changes for renaming a file
-		if _, err := io.CopyBuffer(h, strings.NewReader(filepath.Base(f)), buf); err != nil {
-			return "", err
+		if _, err := io.CopyBuffer(h, strings.NewReader(filepath.Base(path)), buf); err != nil {
+			return "", nil, err
 		}
-		f, err := os.Open(f)
+		f, err := os.Open(path)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
-		if _, err = io.CopyBuffer(h, f, buf); err != nil {
-			return "", err
+		fh := xxh3.New()
+		if _, err = io.CopyBuffer(io.MultiWriter(h, fh), f, buf); err != nil {
+			f.Close()
+			return "", nil, err
 		}
 		f.Close()
+		sum := fh.Sum128()
+		list[relSourcePath(t, path)] = fmt.Sprintf("%x%x", sum.Hi, sum.Lo)
+	}
+	if err := addTrackedInputs(t, h, list); err != nil {
+		return "", nil, err
 	}
 
 	hash := h.Sum128()
-	return fmt.Sprintf("%x%x", hash.Hi, hash.Lo), nil
+	return fmt.Sprintf("%x%x", hash.Hi, hash.Lo), list, nil
 }
 
 func (checker *ChecksumChecker) checksumFilePath(t *ast.Task) string {
@@ -0,0 +1,129 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/internal/execext"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// ExecChecker validates if a task is up to date by running its fingerprint
+// command and comparing what it prints with the output of the last run. The
+// tracked inputs of the task are stored in a source list next to the output.
+type ExecChecker struct {
+	tempDir string
+	dry     bool
+}
+
+func NewExecChecker(tempDir string, dry bool) *ExecChecker {
+	return &ExecChecker{
+		tempDir: tempDir,
+		dry:     dry,
+	}
+}
+
+func (checker *ExecChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
+	fingerprintFile := checker.fingerprintFilePath(t)
+
+	data, err := os.ReadFile(fingerprintFile)
+	ranBefore := err == nil
+	oldValue := strings.TrimSpace(string(data))
+
+	newValue, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return false, err
+	}
+
+	inputs := trackedInputs(t)
+	oldInputs, _ := readSourceList(sourceListPath(fingerprintFile))
+	upToDate := ranBefore && oldValue == newValue && len(inputs.changes(oldInputs)) == 0
+
+	if !checker.dry && !upToDate {
+		_ = os.MkdirAll(filepathext.SmartJoin(checker.tempDir, "exec"), 0o755)
+		if err = os.WriteFile(fingerprintFile, []byte(newValue+"\n"), 0o644); err != nil {
+			return false, err
+		}
+		if err = writeSourceList(sourceListPath(fingerprintFile), inputs); err != nil {
+			return false, err
+		}
+	}
+
+	if ok, err := generatesExist(t); !ok || err != nil {
+		return false, err
+	}
+
+	return upToDate, nil
+}
+
+// Explain implements the SourcesExplainable interface
+func (checker *ExecChecker) Explain(ctx context.Context, t *ast.Task) (*SourcesExplanation, error) {
+	newValue, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	explanation := &SourcesExplanation{MissingGenerates: missingGenerates(t)}
+	fingerprintFile := checker.fingerprintFilePath(t)
+	data, err := os.ReadFile(fingerprintFile)
+	if err != nil {
+		explanation.NeverRan = true
+		return explanation, nil
+	}
+	if oldValue := strings.TrimSpace(string(data)); oldValue != newValue {
+		explanation.Reason = fmt.Sprintf("the fingerprint command printed %q instead of %q", newValue, oldValue)
+	}
+	oldInputs, _ := readSourceList(sourceListPath(fingerprintFile))
+	explanation.Changed = trackedInputs(t).changes(oldInputs)
+	explanation.UpToDate = explanation.Reason == "" && len(explanation.Changed) == 0 && len(explanation.MissingGenerates) == 0
+	return explanation, nil
+}
+
+func (checker *ExecChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	return checker.fingerprint(ctx, t)
+}
+
+func (checker *ExecChecker) OnError(t *ast.Task) error {
+	if t.Fingerprint == "" {
+		return nil
+	}
+	_ = os.Remove(sourceListPath(checker.fingerprintFilePath(t)))
+	return os.Remove(checker.fingerprintFilePath(t))
+}
+
+func (*ExecChecker) Kind() string {
+	return "exec"
+}
+
+// NeedsSources implements the SourcesCheckable interface. The fingerprint
+// command replaces the sources.
+func (*ExecChecker) NeedsSources() bool {
+	return false
+}
+
+func (checker *ExecChecker) fingerprint(ctx context.Context, t *ast.Task) (string, error) {
+	if t.Fingerprint == "" {
+		return "", fmt.Errorf(`task: Task "%s" uses the "exec" method but has no fingerprint command`, t.Name())
+	}
+	var stdout, stderr bytes.Buffer
+	err := execext.RunCommand(ctx, &execext.RunCommandOptions{
+		Command: t.Fingerprint,
+		Dir:     t.Dir,
+		Env:     env.Get(t),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	if err != nil {
+		return "", fmt.Errorf(`task: fingerprint command of task "%s" failed: %w: %s`, t.Name(), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (checker *ExecChecker) fingerprintFilePath(t *ast.Task) string {
+	return filepath.Join(checker.tempDir, "exec", normalizeFilename(t.Name()))
+}
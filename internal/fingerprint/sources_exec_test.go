@@ -0,0 +1,79 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestExecChecker(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tempDir := filepath.Join(dir, ".task")
+	versionFile := filepath.Join(dir, "version.txt")
+	require.NoError(t, os.WriteFile(versionFile, []byte("1\n"), 0o644))
+
+	task := &ast.Task{
+		Task:        "build",
+		Dir:         dir,
+		Fingerprint: "cat version.txt",
+	}
+
+	// Dry runs don't store the fingerprint
+	upToDate, err := NewExecChecker(tempDir, true).IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	assert.NoFileExists(t, filepath.Join(tempDir, "exec", "build"))
+
+	checker := NewExecChecker(tempDir, false)
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+
+	value, err := checker.Value(t.Context(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "1", value)
+
+	require.NoError(t, os.WriteFile(versionFile, []byte("2\n"), 0o644))
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+
+	// A task that failed must run again
+	require.NoError(t, checker.OnError(task))
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+
+	// Tracked variables are part of the fingerprint too
+	task.Track = &ast.Track{Vars: []string{"GOOS"}}
+	task.Vars = ast.NewVars(&ast.VarElement{Key: "GOOS", Value: ast.Var{Value: "linux"}})
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+
+	task.Vars.Set("GOOS", ast.Var{Value: "windows"})
+	explanation, err := checker.Explain(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, explanation.UpToDate)
+	assert.Equal(t, []SourceChange{{Path: "var:GOOS", Change: SourceModified}}, explanation.Changed)
+	upToDate, err = checker.IsUpToDate(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+
+	_, err = checker.IsUpToDate(t.Context(), &ast.Task{Task: "empty", Dir: dir})
+	require.ErrorContains(t, err, "has no fingerprint command")
+}
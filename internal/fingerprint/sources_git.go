@@ -0,0 +1,274 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/xxh3"
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/go-task/task/v3/internal/execext"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// GitChecker validates if a task is up to date by comparing the git blob hashes
+// of its source files. The hashes of tracked files are read from the index,
+// while files that are untracked or modified in the working tree are hashed
+// by git hash-object. Outside a git repository it works like the
+// [ChecksumChecker].
+type GitChecker struct {
+	tempDir string
+	dry     bool
+}
+
+func NewGitChecker(tempDir string, dry bool) *GitChecker {
+	return &GitChecker{
+		tempDir: tempDir,
+		dry:     dry,
+	}
+}
+
+func (checker *GitChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
+	if len(t.Sources) == 0 {
+		return false, nil
+	}
+
+	fingerprintFile := checker.fingerprintFilePath(t)
+
+	data, _ := os.ReadFile(fingerprintFile)
+	oldHash := strings.TrimSpace(string(data))
+
+	newHash, list, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return false, nil
+	}
+
+	if !checker.dry && oldHash != newHash {
+		_ = os.MkdirAll(filepathext.SmartJoin(checker.tempDir, "git"), 0o755)
+		if err = os.WriteFile(fingerprintFile, []byte(newHash+"\n"), 0o644); err != nil {
+			return false, err
+		}
+		if err = writeSourceList(sourceListPath(fingerprintFile), list); err != nil {
+			return false, err
+		}
+	}
+
+	if ok, err := generatesExist(t); !ok || err != nil {
+		return false, err
+	}
+
+	return oldHash == newHash, nil
+}
+
+// Explain implements the SourcesExplainable interface
+func (checker *GitChecker) Explain(ctx context.Context, t *ast.Task) (*SourcesExplanation, error) {
+	newHash, list, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	return explainSourceList(t, checker.fingerprintFilePath(t), newHash, list), nil
+}
+
+func (checker *GitChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	hash, _, err := checker.fingerprint(ctx, t)
+	return hash, err
+}
+
+func (checker *GitChecker) OnError(t *ast.Task) error {
+	if len(t.Sources) == 0 {
+		return nil
+	}
+	_ = os.Remove(sourceListPath(checker.fingerprintFilePath(t)))
+	return os.Remove(checker.fingerprintFilePath(t))
+}
+
+func (*GitChecker) Kind() string {
+	return "git"
+}
+
+func (*GitChecker) NeedsSources() bool {
+	return true
+}
+
+// fingerprint returns the fingerprint of the sources and the tracked inputs of
+// the task, along with the blob hash of each of them
+func (checker *GitChecker) fingerprint(ctx context.Context, t *ast.Task) (string, sourceList, error) {
+	sources, err := Globs(t.Dir, t.Sources)
+	if err != nil {
+		return "", nil, err
+	}
+
+	repo, err := gitIndex(ctx, t.Dir, t.Sources)
+	if err != nil {
+		// Not in a git repository, or git is not installed
+		return NewChecksumChecker(checker.tempDir, checker.dry).checksum(t)
+	}
+
+	paths := make([]string, len(sources))
+	blobs := make([]string, len(sources))
+	var unstaged []string
+	for i, f := range sources {
+		if paths[i], err = repo.rel(f); err != nil {
+			return "", nil, err
+		}
+		blob, ok := repo.blobs[paths[i]]
+		if !ok || repo.modified[paths[i]] {
+			unstaged = append(unstaged, paths[i])
+			continue
+		}
+		blobs[i] = blob
+	}
+	hashes, err := repo.hashObjects(ctx, unstaged)
+	if err != nil {
+		return "", nil, err
+	}
+
+	h := xxh3.New()
+	list := make(sourceList, len(sources))
+	for i, f := range sources {
+		if blobs[i] == "" {
+			blobs[i], hashes = hashes[0], hashes[1:]
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", paths[i], blobs[i])
+		list[relSourcePath(t, f)] = blobs[i]
+	}
+	if err := addTrackedInputs(t, h, list); err != nil {
+		return "", nil, err
+	}
+
+	sum := h.Sum128()
+	return fmt.Sprintf("%x%x", sum.Hi, sum.Lo), list, nil
+}
+
+func (checker *GitChecker) fingerprintFilePath(t *ast.Task) string {
+	return filepath.Join(checker.tempDir, "git", normalizeFilename(t.Name()))
+}
+
+// gitRepo holds the state of the source files of a task in the index of a git
+// repository
+type gitRepo struct {
+	// root is the top level directory of the working tree
+	root string
+	// blobs maps the paths of the tracked files, relative to root, to the hash
+	// of their staged contents
+	blobs map[string]string
+	// modified holds the paths of the tracked files whose contents in the
+	// working tree differ from the index
+	modified map[string]bool
+}
+
+// gitIndex reads the entries of the files matched by the given globs in the
+// index of the git repository that contains dir.
+func gitIndex(ctx context.Context, dir string, globs []*ast.Glob) (*gitRepo, error) {
+	cdup, err := runGit(ctx, dir, "git rev-parse --show-cdup", "")
+	if err != nil {
+		return nil, err
+	}
+	repo := &gitRepo{
+		// Joining dir keeps the root consistent with the paths returned by
+		// Globs, even when dir goes through a symlink
+		root:     filepath.Join(dir, strings.TrimSpace(cdup)),
+		blobs:    map[string]string{},
+		modified: map[string]bool{},
+	}
+
+	// The globs become pathspecs, so that only the sources are listed. Files
+	// that they miss, e.g. because git doesn't support the syntax of the glob,
+	// are hashed like untracked files.
+	var pathspecs []string
+	for _, g := range globs {
+		if g.Negate {
+			continue
+		}
+		rel, err := repo.rel(filepathext.SmartJoin(dir, g.Glob))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		pathspec, err := syntax.Quote(":(glob)"+rel, syntax.LangBash)
+		if err != nil {
+			return nil, err
+		}
+		pathspecs = append(pathspecs, pathspec)
+	}
+	if len(pathspecs) == 0 {
+		return repo, nil
+	}
+
+	args := strings.Join(pathspecs, " ")
+	staged, err := runGit(ctx, repo.root, "git ls-files --stage -z -- "+args, "")
+	if err != nil {
+		return nil, err
+	}
+	modified, err := runGit(ctx, repo.root, "git ls-files --modified -z -- "+args, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for entry := range strings.SplitSeq(staged, "\x00") {
+		// Each entry looks like "<mode> <hash> <stage>\t<path>"
+		info, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 3 {
+			continue
+		}
+		repo.blobs[path] = fields[1]
+	}
+	for path := range strings.SplitSeq(modified, "\x00") {
+		if path != "" {
+			repo.modified[path] = true
+		}
+	}
+	return repo, nil
+}
+
+// rel returns the path of the given file relative to the root of the
+// repository, as git prints it.
+func (repo *gitRepo) rel(path string) (string, error) {
+	rel, err := filepath.Rel(repo.root, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// hashObjects returns the hashes that the given files, relative to the root of
+// the repository, have in the index once they are staged. Like git add, git
+// hash-object applies the filters of the repository, such as the conversion of
+// line endings, before hashing.
+func (repo *gitRepo) hashObjects(ctx context.Context, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	out, err := runGit(ctx, repo.root, "git hash-object --stdin-paths", strings.Join(paths, "\n")+"\n")
+	if err != nil {
+		return nil, err
+	}
+	hashes := strings.Fields(out)
+	if len(hashes) != len(paths) {
+		return nil, fmt.Errorf("task: git hash-object printed %d hashes for %d files", len(hashes), len(paths))
+	}
+	return hashes, nil
+}
+
+func runGit(ctx context.Context, dir, command, stdin string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := execext.RunCommand(ctx, &execext.RunCommandOptions{
+		Command: command,
+		Dir:     dir,
+		Stdin:   strings.NewReader(stdin),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	if err != nil {
+		return "", fmt.Errorf("task: %s failed: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
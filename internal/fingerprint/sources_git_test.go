@@ -0,0 +1,130 @@
+package fingerprint
+
+import (
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestGitChecker(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, contents string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644))
+	}
+
+	write(".gitattributes", "*.txt text eol=lf\n")
+	write("src/a.txt", "a")
+	write("src/b.txt", "b")
+	write("other.txt", "other")
+	git(dir, "init", "-q")
+	git(dir, "add", ".")
+	git(dir, "-c", "user.name=task", "-c", "user.email=task@example.com", "commit", "-q", "-m", "initial")
+
+	task := &ast.Task{
+		Task:    "build",
+		Dir:     filepath.Join(dir, "src"),
+		Sources: []*ast.Glob{{Glob: "*.txt"}},
+	}
+	checker := NewGitChecker(filepath.Join(t.TempDir(), ".task"), false)
+	isUpToDate := func() bool {
+		t.Helper()
+		upToDate, err := checker.IsUpToDate(t.Context(), task)
+		require.NoError(t, err)
+		return upToDate
+	}
+
+	assert.False(t, isUpToDate())
+	assert.True(t, isUpToDate())
+
+	// Touching a file doesn't change its contents
+	now := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "src/a.txt"), now, now))
+	assert.True(t, isUpToDate())
+
+	// Files modified in the working tree are hashed by their contents, and
+	// staging them doesn't change their hash
+	write("src/a.txt", "changed")
+	assert.False(t, isUpToDate())
+	assert.True(t, isUpToDate())
+	git(dir, "add", ".")
+	assert.True(t, isUpToDate())
+	git(dir, "-c", "user.name=task", "-c", "user.email=task@example.com", "commit", "-q", "-m", "change")
+
+	// Untracked files are hashed by their contents
+	write("src/c.txt", "c")
+	assert.False(t, isUpToDate())
+	write("src/c.txt", "changed")
+	assert.False(t, isUpToDate())
+	assert.True(t, isUpToDate())
+
+	// Only the sources are read from the index
+	repo, err := gitIndex(t.Context(), task.Dir, []*ast.Glob{{Glob: "a.*"}, {Glob: "b.txt", Negate: true}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/a.txt"}, slices.Collect(maps.Keys(repo.blobs)))
+
+	// Files are hashed like git does when staging them, with the line endings
+	// converted
+	write("src/d.txt", "d\r\n")
+	assert.False(t, isUpToDate())
+	assert.True(t, isUpToDate())
+	git(dir, "add", ".")
+	git(dir, "-c", "user.name=task", "-c", "user.email=task@example.com", "commit", "-q", "-m", "crlf")
+	assert.True(t, isUpToDate())
+
+	// The fingerprint doesn't depend on where the repository is
+	value, err := checker.Value(t.Context(), task)
+	require.NoError(t, err)
+	clone := t.TempDir()
+	git(clone, "clone", "-q", dir, ".")
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "src/c.txt"), []byte("changed"), 0o644))
+	cloneValue, err := checker.Value(t.Context(), &ast.Task{
+		Task:    "build",
+		Dir:     filepath.Join(clone, "src"),
+		Sources: task.Sources,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, value, cloneValue)
+}
+
+func TestGitCheckerOutsideRepository(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	task := &ast.Task{
+		Task:    "build",
+		Dir:     dir,
+		Sources: []*ast.Glob{{Glob: "*.txt"}},
+	}
+
+	value, err := NewGitChecker(t.TempDir(), false).Value(t.Context(), task)
+	require.NoError(t, err)
+	checksum, err := NewChecksumChecker(t.TempDir(), false).Value(t.Context(), task)
+	require.NoError(t, err)
+	assert.Equal(t, checksum, value)
+}
@@ -0,0 +1,34 @@
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSourcesChecker(t *testing.T) {
+	t.Parallel()
+
+	var gotTempDir string
+	var gotDry bool
+	factory := func(tempDir string, dry bool) SourcesCheckable {
+		gotTempDir, gotDry = tempDir, dry
+		return NoneChecker{}
+	}
+
+	require.NoError(t, RegisterSourcesChecker("test-registry", factory))
+	checker, err := NewSourcesChecker("test-registry", "/tmp/task", true)
+	require.NoError(t, err)
+	assert.Equal(t, NoneChecker{}, checker)
+	assert.Equal(t, "/tmp/task", gotTempDir)
+	assert.True(t, gotDry)
+
+	require.ErrorContains(t, RegisterSourcesChecker("test-registry", factory), "already registered")
+	require.ErrorContains(t, RegisterSourcesChecker("checksum", factory), "already registered")
+	require.Error(t, RegisterSourcesChecker("", factory))
+	require.Error(t, RegisterSourcesChecker("test-nil", nil))
+
+	_, err = NewSourcesChecker("test-unknown", "", false)
+	require.ErrorContains(t, err, `invalid method "test-unknown"`)
+}
@@ -1,6 +1,7 @@
 package fingerprint
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"time"
@@ -23,7 +24,7 @@ func NewTimestampChecker(tempDir string, dry bool) *TimestampChecker {
 }
 
 // IsUpToDate implements the Checker interface
-func (checker *TimestampChecker) IsUpToDate(t *ast.Task) (bool, error) {
+func (checker *TimestampChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
 	if len(t.Sources) == 0 {
 		return false, nil
 	}
@@ -39,11 +40,18 @@ func (checker *TimestampChecker) IsUpToDate(t *ast.Task) (bool, error) {
 
 	timestampFile := checker.timestampFilePath(t)
 
+	// The timestamp file holds the tracked inputs of the last run. If they
+	// changed, the task will be executed.
+	inputs := trackedInputs(t)
+	inputsChanged := len(inputs) > 0
+
 	// If the file exists, add the file path to the generates.
 	// If the generate file is old, the task will be executed.
 	_, err = os.Stat(timestampFile)
 	if err == nil {
 		generates = append(generates, timestampFile)
+		oldInputs, _ := readSourceList(timestampFile)
+		inputsChanged = len(inputs.changes(oldInputs)) > 0
 	} else {
 		// Create the timestamp file for the next execution when the file does not exist.
 		if !checker.dry {
@@ -74,22 +82,72 @@ func (checker *TimestampChecker) IsUpToDate(t *ast.Task) (bool, error) {
 		return false, nil
 	}
 
-	// Modify the metadata of the file to the the current time.
+	// Store the tracked inputs and modify the metadata of the file to the the
+	// current time.
 	if !checker.dry {
+		if err := writeSourceList(timestampFile, inputs); err != nil {
+			return false, err
+		}
 		if err := os.Chtimes(timestampFile, taskTime, taskTime); err != nil {
 			return false, err
 		}
 	}
 
-	return !shouldUpdate, nil
+	return !shouldUpdate && !inputsChanged, nil
+}
+
+// Explain implements the SourcesExplainable interface
+func (checker *TimestampChecker) Explain(ctx context.Context, t *ast.Task) (*SourcesExplanation, error) {
+	sources, err := Globs(t.Dir, t.Sources)
+	if err != nil {
+		return nil, err
+	}
+	generates, err := Globs(t.Dir, t.Generates)
+	if err != nil {
+		return nil, err
+	}
+	explanation := &SourcesExplanation{}
+	timestampFile := checker.timestampFilePath(t)
+	oldInputs, err := readSourceList(timestampFile)
+	if err == nil {
+		generates = append(generates, timestampFile)
+	}
+
+	generateMaxTime, err := getMaxTime(generates...)
+	if err != nil {
+		return nil, err
+	}
+	if generateMaxTime.IsZero() {
+		explanation.NeverRan = true
+		return explanation, nil
+	}
+	for _, f := range sources {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		if info.ModTime().After(generateMaxTime) {
+			explanation.Changed = append(explanation.Changed, SourceChange{
+				Path:   relSourcePath(t, f),
+				Change: SourceModified,
+			})
+		}
+	}
+	explanation.Changed = append(explanation.Changed, trackedInputs(t).changes(oldInputs)...)
+	explanation.UpToDate = len(explanation.Changed) == 0
+	return explanation, nil
 }
 
 func (checker *TimestampChecker) Kind() string {
 	return "timestamp"
 }
 
+func (*TimestampChecker) NeedsSources() bool {
+	return true
+}
+
 // Value implements the Checker Interface
-func (checker *TimestampChecker) Value(t *ast.Task) (any, error) {
+func (checker *TimestampChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
 	sources, err := Globs(t.Dir, t.Sources)
 	if err != nil {
 		return time.Now(), err
@@ -0,0 +1,99 @@
+package fingerprint
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// stateDirs are the directories of the temporary directory where the built-in
+// methods store the fingerprints of tasks, and where the hashes of their
+// definitions are stored
+var stateDirs = []string{"checksum", "definition", "exec", "git", "timestamp"}
+
+// Entry is the fingerprint of a task stored by a method.
+type Entry struct {
+	Method string
+	// Name is the name of the task, as normalized for file names
+	Name string
+	// Paths are the files that make up the entry
+	Paths []string
+	// Size is the total size of the files, in bytes
+	Size int64
+}
+
+// Entries returns the fingerprints stored in the given temporary directory,
+// sorted by method and name.
+func Entries(tempDir string) ([]*Entry, error) {
+	var entries []*Entry
+	for _, method := range stateDirs {
+		dir := filepath.Join(tempDir, method)
+		files, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		byName := map[string]*Entry{}
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			info, err := f.Info()
+			if err != nil {
+				return nil, err
+			}
+			name := strings.TrimSuffix(f.Name(), sourceListSuffix)
+			entry, ok := byName[name]
+			if !ok {
+				entry = &Entry{Method: method, Name: name}
+				byName[name] = entry
+				entries = append(entries, entry)
+			}
+			entry.Paths = append(entry.Paths, filepath.Join(dir, f.Name()))
+			entry.Size += info.Size()
+		}
+	}
+	slices.SortFunc(entries, func(a, b *Entry) int {
+		if a.Method != b.Method {
+			return strings.Compare(a.Method, b.Method)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries, nil
+}
+
+// Remove deletes the files of the entry.
+func (entry *Entry) Remove() error {
+	var errs []error
+	for _, path := range entry.Paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var namePlaceholderRegexp = regexp.MustCompile(`\{\{.*?\}\}|\*`)
+
+// NamePattern returns a pattern that matches the names of the entries stored
+// for a task with the given name or label. Templates and wildcards in the name
+// match anything, as the name of the entry depends on how the task was called.
+func NamePattern(name string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, loc := range namePlaceholderRegexp.FindAllStringIndex(name, -1) {
+		b.WriteString(regexp.QuoteMeta(normalizeFilename(name[last:loc[0]])))
+		b.WriteString(".*")
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(normalizeFilename(name[last:])))
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
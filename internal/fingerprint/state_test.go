@@ -0,0 +1,70 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		matches []string
+		others  []string
+	}{
+		{"build", []string{"build"}, []string{"build-1", "rebuild"}},
+		{"docs:site", []string{"docs-site"}, []string{"docs-site-1"}},
+		{"gen-*", []string{"gen-", "gen-foo"}, []string{"gen"}},
+		{"lint-{{.TARGET}}", []string{"lint-", "lint-app"}, []string{"lint"}},
+	}
+	for _, test := range tests {
+		pattern := NamePattern(test.name)
+		for _, name := range test.matches {
+			assert.True(t, pattern.MatchString(name), "%s should match %s", test.name, name)
+		}
+		for _, name := range test.others {
+			assert.False(t, pattern.MatchString(name), "%s should not match %s", test.name, name)
+		}
+	}
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, contents string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+	write("checksum/build", "1234\n")
+	write("checksum/build.files", "1234  a.txt\n")
+	write("timestamp/build", "")
+	write("cache/ac/abc", "{}")
+
+	entries, err := Entries(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, &Entry{
+		Method: "checksum",
+		Name:   "build",
+		Paths:  []string{filepath.Join(dir, "checksum/build"), filepath.Join(dir, "checksum/build.files")},
+		Size:   17,
+	}, entries[0])
+	assert.Equal(t, "timestamp", entries[1].Method)
+
+	require.NoError(t, entries[0].Remove())
+	entries, err = Entries(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entries, err = Entries(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -16,6 +16,7 @@ type (
 		logger         *logger.Logger
 		statusChecker  StatusCheckable
 		sourcesChecker SourcesCheckable
+		definition     *ast.Task
 	}
 )
 
@@ -55,6 +56,16 @@ func WithSourcesChecker(checker SourcesCheckable) CheckerOption {
 	}
 }
 
+// WithDefinition sets the task as written in the Taskfile, before it is
+// compiled. The hash of its definition is stored when the task must run, or
+// when none was stored yet, so that [ExplainTask] can tell when it changed. It
+// never makes the task run again.
+func WithDefinition(definition *ast.Task) CheckerOption {
+	return func(config *CheckerConfig) {
+		config.definition = definition
+	}
+}
+
 func IsTaskUpToDate(
 	ctx context.Context,
 	t *ast.Task,
@@ -93,7 +104,9 @@ func IsTaskUpToDate(
 	}
 
 	statusIsSet := len(t.Status) != 0
-	sourcesIsSet := len(t.Sources) != 0
+	// Some methods, like exec, run the fingerprint command instead of checking
+	// the sources
+	sourcesIsSet := len(t.Sources) != 0 || (t.Fingerprint != "" && !config.sourcesChecker.NeedsSources())
 
 	// If status is set, check if it is up-to-date
 	if statusIsSet {
@@ -105,28 +118,35 @@ func IsTaskUpToDate(
 
 	// If sources is set, check if they are up-to-date
 	if sourcesIsSet {
-		sourcesUpToDate, err = config.sourcesChecker.IsUpToDate(t)
+		sourcesUpToDate, err = config.sourcesChecker.IsUpToDate(ctx, t)
 		if err != nil {
 			return false, err
 		}
 	}
 
+	var upToDate bool
+	switch {
 	// If both status and sources are set, the task is up-to-date if both are up-to-date
-	if statusIsSet && sourcesIsSet {
-		return statusUpToDate && sourcesUpToDate, nil
-	}
-
+	case statusIsSet && sourcesIsSet:
+		upToDate = statusUpToDate && sourcesUpToDate
 	// If only status is set, the task is up-to-date if the status is up-to-date
-	if statusIsSet {
-		return statusUpToDate, nil
+	case statusIsSet:
+		upToDate = statusUpToDate
+	// If only sources is set, the task is up-to-date if the sources are up-to-date
+	case sourcesIsSet:
+		upToDate = sourcesUpToDate
 	}
 
-	// If only sources is set, the task is up-to-date if the sources are up-to-date
-	if sourcesIsSet {
-		return sourcesUpToDate, nil
+	// The task runs with the current definition, or it is up-to-date and no
+	// definition was stored yet
+	if sourcesIsSet && !config.dry && config.definition != nil &&
+		(!upToDate || !definitionStored(config.tempDir, t)) {
+		if err := storeDefinition(config.tempDir, t, config.definition); err != nil {
+			return false, err
+		}
 	}
 
 	// If no status or sources are set, the task should always run
 	// i.e. it is never considered "up-to-date"
-	return false, nil
+	return upToDate, nil
 }
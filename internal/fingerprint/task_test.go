@@ -51,7 +51,7 @@ func TestIsTaskUpToDate(t *testing.T) {
 			},
 			setupMockStatusChecker: nil,
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: true,
 		},
@@ -63,7 +63,7 @@ func TestIsTaskUpToDate(t *testing.T) {
 			},
 			setupMockStatusChecker: nil,
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			expected: false,
 		},
@@ -89,7 +89,7 @@ func TestIsTaskUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: true,
 		},
@@ -103,7 +103,7 @@ func TestIsTaskUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			expected: false,
 		},
@@ -129,7 +129,7 @@ func TestIsTaskUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: false,
 		},
@@ -143,7 +143,30 @@ func TestIsTaskUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
+			},
+			expected: false,
+		},
+		{
+			name: "expect TRUE when a fingerprint command is up-to-date without sources",
+			task: &ast.Task{
+				Fingerprint: "git rev-parse HEAD",
+			},
+			setupMockStatusChecker: nil,
+			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
+				m.EXPECT().NeedsSources().Return(false)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
+			},
+			expected: true,
+		},
+		{
+			name: "expect FALSE when a fingerprint command is ignored without sources",
+			task: &ast.Task{
+				Fingerprint: "git rev-parse HEAD",
+			},
+			setupMockStatusChecker: nil,
+			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
+				m.EXPECT().NeedsSources().Return(true)
 			},
 			expected: false,
 		},
@@ -0,0 +1,124 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// trackedInputs returns the hashes of the values of the variables and the
+// environment variables tracked by the task, keyed by "var:<name>" and
+// "env:<name>". Those that aren't set are tracked too, so that setting them
+// changes the fingerprint.
+func trackedInputs(t *ast.Task) sourceList {
+	if t.Track == nil || (len(t.Track.Vars) == 0 && len(t.Track.Env) == 0) {
+		return nil
+	}
+	inputs := sourceList{}
+	for _, name := range t.Track.Vars {
+		var value any
+		if v, ok := t.Vars.Get(name); ok {
+			value = v.Value
+		}
+		inputs["var:"+name] = inputHash(value)
+	}
+	if len(t.Track.Env) > 0 {
+		// Use the environment that the commands of the task get
+		environ := os.Environ()
+		if t.Env != nil {
+			environ = env.Get(t)
+		}
+		values := map[string]string{}
+		for _, kv := range environ {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				values[k] = v
+			}
+		}
+		for _, name := range t.Track.Env {
+			var value any
+			if v, ok := values[name]; ok {
+				value = v
+			}
+			inputs["env:"+name] = inputHash(value)
+		}
+	}
+	return inputs
+}
+
+func inputHash(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		b = []byte(fmt.Sprint(value))
+	}
+	hash := xxh3.Hash128(b)
+	return fmt.Sprintf("%x%x", hash.Hi, hash.Lo)
+}
+
+type (
+	// definition holds what a task does, as written in the Taskfile. The
+	// variables and the environment are left out, as they are tracked apart.
+	definition struct {
+		Cmds      []command   `json:"cmds"`
+		Deps      []command   `json:"deps,omitempty"`
+		Status    []string    `json:"status,omitempty"`
+		Sources   []*ast.Glob `json:"sources,omitempty"`
+		Generates []*ast.Glob `json:"generates,omitempty"`
+		Set       []string    `json:"set,omitempty"`
+		Shopt     []string    `json:"shopt,omitempty"`
+	}
+	command struct {
+		Cmd  string         `json:"cmd,omitempty"`
+		Task string         `json:"task,omitempty"`
+		Vars map[string]any `json:"vars,omitempty"`
+	}
+)
+
+// definitionHash returns the hash of the definition of the given task, before
+// it is compiled, so that templates whose values change on every run, like
+// dynamic variables or now, don't change it
+func definitionHash(t *ast.Task) string {
+	def := definition{
+		Status:    t.Status,
+		Sources:   t.Sources,
+		Generates: t.Generates,
+		Set:       t.Set,
+		Shopt:     t.Shopt,
+	}
+	for _, cmd := range t.Cmds {
+		c := command{Cmd: cmd.Cmd, Task: cmd.Task}
+		if cmd.Vars != nil {
+			c.Vars = cmd.Vars.ToCacheMap()
+		}
+		def.Cmds = append(def.Cmds, c)
+	}
+	for _, dep := range t.Deps {
+		d := command{Task: dep.Task}
+		if dep.Vars != nil {
+			d.Vars = dep.Vars.ToCacheMap()
+		}
+		def.Deps = append(def.Deps, d)
+	}
+	return inputHash(def)
+}
+
+// addTrackedInputs adds the tracked inputs of the task to the fingerprint
+// written to h and to the list of its sources.
+func addTrackedInputs(t *ast.Task, h io.Writer, list sourceList) error {
+	inputs := trackedInputs(t)
+	for _, key := range slices.Sorted(maps.Keys(inputs)) {
+		if _, err := fmt.Fprintf(h, "%s\x00%s\n", key, inputs[key]); err != nil {
+			return err
+		}
+		list[key] = inputs[key]
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestTrackedInputs(t *testing.T) {
+	t.Parallel()
+
+	vars := ast.NewVars()
+	vars.Set("GOOS", ast.Var{Value: "linux"})
+	env := ast.NewVars()
+	env.Set("TASK_TEST_TRACKED", ast.Var{Value: "0"})
+	task := &ast.Task{
+		Vars:  vars,
+		Env:   env,
+		Track: &ast.Track{Vars: []string{"GOOS", "UNSET"}, Env: []string{"TASK_TEST_TRACKED"}},
+	}
+
+	inputs := trackedInputs(task)
+	assert.Len(t, inputs, 3)
+	assert.Equal(t, inputHash("linux"), inputs["var:GOOS"])
+	assert.Equal(t, inputHash(nil), inputs["var:UNSET"])
+	assert.Equal(t, inputHash("0"), inputs["env:TASK_TEST_TRACKED"])
+
+	vars.Set("GOOS", ast.Var{Value: "windows"})
+	assert.Equal(t, []SourceChange{{Path: "var:GOOS", Change: SourceModified}}, trackedInputs(task).changes(inputs))
+
+	assert.Nil(t, trackedInputs(&ast.Task{}))
+}
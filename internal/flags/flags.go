@@ -2,6 +2,7 @@ package flags
 
 import (
 	"cmp"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -48,23 +49,33 @@ var (
 	Help                bool
 	Init                bool
 	Completion          string
+	LSP                 bool
+	Schema              string
+	Fmt                 bool
+	Check               bool
 	List                bool
 	ListAll             bool
 	ListJson            bool
 	TaskSort            string
 	Status              bool
+	Why                 bool
+	CleanFingerprints   bool
+	Pick                bool
 	NoStatus            bool
 	Nested              bool
 	Insecure            bool
 	Force               bool
 	ForceAll            bool
 	Watch               bool
+	Poll                bool
+	WatchIgnore         []string
 	Verbose             bool
 	Silent              bool
 	DisableFuzzy        bool
 	AssumeYes           bool
 	Dry                 bool
 	Summary             bool
+	Describe            bool
 	ExitCode            bool
 	Parallel            bool
 	Concurrency         int
@@ -87,6 +98,16 @@ var (
 	Cert                string
 	CertKey             string
 	Interactive         bool
+	Events              string
+	EventsFile          string
+	SummaryReport       string
+	SummaryReportFile   string
+	JUnit               string
+	Trace               string
+	Graph               string
+	ValidateTaskfile    bool
+	BuildCacheURL       string
+	BuildCacheReadOnly  bool
 )
 
 func init() {
@@ -123,15 +144,24 @@ func init() {
 	pflag.BoolVarP(&Help, "help", "h", false, "Shows Task usage.")
 	pflag.BoolVarP(&Init, "init", "i", false, "Creates a new Taskfile.yml in the current folder.")
 	pflag.StringVar(&Completion, "completion", "", "Generates shell completion script.")
+	pflag.BoolVar(&LSP, "lsp", false, "Starts a Language Server Protocol server for Taskfiles over stdin and stdout.")
+	pflag.StringVar(&Schema, "schema", "", "Prints the JSON Schema of Taskfiles or .taskrc files: [taskfile|taskrc].")
+	pflag.Lookup("schema").NoOptDefVal = "taskfile"
+	pflag.BoolVar(&Fmt, "fmt", false, "Formats the Taskfile, or prints it formatted if it is read from stdin.")
+	pflag.BoolVar(&Check, "check", false, "Checks that the Taskfile is formatted instead of formatting it. Used with --fmt.")
 	pflag.BoolVarP(&List, "list", "l", false, "Lists tasks with description of current Taskfile.")
 	pflag.BoolVarP(&ListAll, "list-all", "a", false, "Lists tasks with or without a description.")
-	pflag.BoolVarP(&ListJson, "json", "j", false, "Formats task list as JSON.")
+	pflag.BoolVarP(&ListJson, "json", "j", false, "Formats task list or validation findings as JSON.")
 	pflag.StringVar(&TaskSort, "sort", "", "Changes the order of the tasks when listed. [default|alphanumeric|none].")
 	pflag.BoolVar(&Status, "status", false, "Exits with non-zero exit code if any of the given tasks is not up-to-date.")
+	pflag.BoolVar(&Why, "why", false, "Explains why the given tasks are or aren't up-to-date, without running them.")
+	pflag.BoolVar(&CleanFingerprints, "clean-fingerprints", false, "Removes the fingerprints of the given tasks or namespaces, or of the tasks that no longer exist if none are given.")
+	pflag.BoolVar(&Pick, "pick", false, "Opens a searchable list of the tasks to pick the one to run.")
 	pflag.BoolVar(&NoStatus, "no-status", false, "Ignore status when listing tasks as JSON")
 	pflag.BoolVar(&Nested, "nested", false, "Nest namespaces when listing tasks as JSON")
 	pflag.BoolVar(&Insecure, "insecure", getConfig(config, func() *bool { return config.Remote.Insecure }, false), "Forces Task to download Taskfiles over insecure connections.")
 	pflag.BoolVarP(&Watch, "watch", "w", false, "Enables watch of the given task.")
+	pflag.BoolVar(&Poll, "poll", getConfig(config, func() *bool { return config.Watch.Poll }, false), "Watches for changes by polling the file system instead of relying on its events.")
 	pflag.BoolVarP(&Verbose, "verbose", "v", getConfig(config, func() *bool { return config.Verbose }, false), "Enables verbose mode.")
 	pflag.BoolVarP(&Silent, "silent", "s", false, "Disables echoing.")
 	pflag.BoolVar(&DisableFuzzy, "disable-fuzzy", getConfig(config, func() *bool { return config.DisableFuzzy }, false), "Disables fuzzy matching for task names.")
@@ -140,6 +170,7 @@ func init() {
 	pflag.BoolVarP(&Parallel, "parallel", "p", false, "Executes tasks provided on command line in parallel.")
 	pflag.BoolVarP(&Dry, "dry", "n", false, "Compiles and prints tasks in the order that they would be run, without executing them.")
 	pflag.BoolVar(&Summary, "summary", false, "Show summary about a task.")
+	pflag.BoolVar(&Describe, "describe", false, "Describes the given tasks once compiled, with their variables, commands and dependencies. Used with --json.")
 	pflag.BoolVarP(&ExitCode, "exit-code", "x", false, "Pass-through the exit code of the task command.")
 	pflag.StringVarP(&Dir, "dir", "d", "", "Sets the directory in which Task will execute and look for a Taskfile.")
 	pflag.StringVarP(&Entrypoint, "taskfile", "t", "", `Choose which Taskfile to run. Defaults to "Taskfile.yml".`)
@@ -153,6 +184,16 @@ func init() {
 	pflag.BoolVarP(&Failfast, "failfast", "F", getConfig(config, func() *bool { return &config.Failfast }, false), "When running tasks in parallel, stop all tasks if one fails.")
 	pflag.BoolVarP(&Global, "global", "g", false, "Runs global Taskfile, from $HOME/{T,t}askfile.{yml,yaml}.")
 	pflag.BoolVar(&Experiments, "experiments", false, "Lists all the available experiments and whether or not they are enabled.")
+	pflag.StringVar(&Events, "events", "", "Emits an event stream of the run in the given format: [json].")
+	pflag.StringVar(&EventsFile, "events-file", "", `File to write the event stream to. Use "fd:N" to write to an open file descriptor.`)
+	pflag.StringVar(&SummaryReport, "summary-report", "", "Prints a report of the tasks that ran and their timings at the end of the run: [table|json].")
+	pflag.Lookup("summary-report").NoOptDefVal = "table"
+	pflag.StringVar(&SummaryReportFile, "summary-report-file", "", "File to write the summary report to instead of STDERR.")
+	pflag.StringVar(&JUnit, "junit", "", "Writes a JUnit XML report of the tasks that ran to the given file.")
+	pflag.StringVar(&Graph, "graph", "", "Prints the graph of the tasks and the tasks they call: [dot|mermaid|json].")
+	pflag.Lookup("graph").NoOptDefVal = "dot"
+	pflag.BoolVar(&ValidateTaskfile, "validate", false, "Checks the Taskfile and its included Taskfiles for problems without running any task.")
+	pflag.StringVar(&Trace, "trace", "", "Writes a trace of the run in the Chrome Trace Event Format to the given file.")
 
 	// Gentle force experiment will override the force flag and add a new force-all flag
 	if experiments.GentleForce.Enabled() {
@@ -175,6 +216,19 @@ func init() {
 		pflag.StringVar(&Cert, "cert", getConfig(config, func() *string { return config.Remote.Cert }, ""), "Path to a client certificate for HTTPS connections.")
 		pflag.StringVar(&CertKey, "cert-key", getConfig(config, func() *string { return config.Remote.CertKey }, ""), "Path to a client certificate key for HTTPS connections.")
 	}
+
+	// The build cache is only configured in the config files. It uses the TLS
+	// settings of remote Taskfiles, even when their flags are not available,
+	// in which case they are only read if the build cache is shared.
+	BuildCacheURL = getConfig(config, func() *string { return config.Remote.BuildCacheURL }, "")
+	BuildCacheReadOnly = getConfig(config, func() *bool { return config.Remote.BuildCacheReadOnly }, false)
+	if !experiments.RemoteTaskfiles.Enabled() && BuildCacheURL != "" {
+		CACert = getConfig(config, func() *string { return config.Remote.CACert }, "")
+		Cert = getConfig(config, func() *string { return config.Remote.Cert }, "")
+		CertKey = getConfig(config, func() *string { return config.Remote.CertKey }, "")
+	}
+	// Watch ignore patterns are only configured in the config files
+	WatchIgnore = getConfig(config, func() *[]string { return &config.Watch.Ignore }, nil)
 	pflag.Parse()
 
 	// Auto-detect color based on environment when not explicitly configured
@@ -230,8 +284,16 @@ func Validate() error {
 		return errors.New("task: cannot use --list and --list-all at the same time")
 	}
 
-	if ListJson && !List && !ListAll {
-		return errors.New("task: --json only applies to --list or --list-all")
+	if Pick && (List || ListAll || Status || Why || Summary) {
+		return errors.New("task: --pick can't be used with --list, --list-all, --status, --why or --summary")
+	}
+
+	if ListJson && !List && !ListAll && !ValidateTaskfile && !Describe {
+		return errors.New("task: --json only applies to --list, --list-all, --validate or --describe")
+	}
+
+	if Describe && !ListJson {
+		return errors.New("task: --describe requires --json")
 	}
 
 	if NoStatus && !ListJson {
@@ -242,6 +304,38 @@ func Validate() error {
 		return errors.New("task: --nested only applies to --json with --list or --list-all")
 	}
 
+	if Events != "" && Events != "json" {
+		return fmt.Errorf("task: unsupported event stream format %q", Events)
+	}
+
+	if Events != "" && EventsFile == "" {
+		return errors.New("task: --events requires --events-file")
+	}
+
+	if EventsFile != "" && Events == "" {
+		return errors.New("task: --events-file only applies to --events")
+	}
+
+	if Check && !Fmt {
+		return errors.New("task: --check only applies to --fmt")
+	}
+
+	if Schema != "" && Schema != "taskfile" && Schema != "taskrc" {
+		return fmt.Errorf("task: unsupported schema %q", Schema)
+	}
+
+	if Graph != "" && Graph != "dot" && Graph != "mermaid" && Graph != "json" {
+		return fmt.Errorf("task: unsupported graph format %q", Graph)
+	}
+
+	if SummaryReport != "" && SummaryReport != "table" && SummaryReport != "json" {
+		return fmt.Errorf("task: unsupported summary report format %q", SummaryReport)
+	}
+
+	if SummaryReportFile != "" && SummaryReport == "" {
+		return errors.New("task: --summary-report-file only applies to --summary-report")
+	}
+
 	// Validate certificate flags
 	if (Cert != "" && CertKey == "") || (Cert == "" && CertKey != "") {
 		return errors.New("task: --cert and --cert-key must be provided together")
@@ -292,13 +386,17 @@ func (o *flagsOption) ApplyToExecutor(e *task.Executor) {
 		task.WithCACert(CACert),
 		task.WithCert(Cert),
 		task.WithCertKey(CertKey),
+		task.WithBuildCacheURL(BuildCacheURL),
+		task.WithBuildCacheReadOnly(BuildCacheReadOnly),
 		task.WithWatch(Watch),
+		task.WithWatchPoll(Poll),
+		task.WithWatchIgnore(WatchIgnore),
 		task.WithVerbose(Verbose),
 		task.WithSilent(Silent),
 		task.WithDisableFuzzy(DisableFuzzy),
 		task.WithAssumeYes(AssumeYes),
 		task.WithInteractive(Interactive),
-		task.WithDry(Dry || Status),
+		task.WithDry(Dry || Status || Why),
 		task.WithSummary(Summary),
 		task.WithParallel(Parallel),
 		task.WithColor(Color),
@@ -0,0 +1,450 @@
+// Package format formats Taskfiles in a canonical style. The Taskfiles are
+// formatted through their YAML nodes, so that their comments and anchors are
+// kept.
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+var (
+	// rootOrder is the order of the keys of a Taskfile, as suggested by the
+	// style guide
+	rootOrder = []string{
+		"version", "includes", "output", "silent", "method", "run", "interval",
+		"set", "shopt", "vars", "env", "dotenv", "tasks",
+	}
+	// taskOrder is the order of the keys of a task: what describes the task
+	// first, then what it needs, when it runs and finally what it runs
+	taskOrder = []string{
+		"desc", "summary", "aliases", "label", "prompt", "internal", "silent",
+		"interactive", "platforms", "if", "requires", "dir", "vars", "env",
+		"dotenv", "deps", "preconditions", "method", "fingerprint", "track",
+		"sources", "generates", "status", "run", "set", "shopt", "prefix",
+		"ignore_error", "failfast", "timeout", "retry", "cache", "watch", "cmd",
+		"cmds",
+	}
+	// nameListKeys are the keys of a task whose values are lists of names,
+	// written in flow style when they only hold scalars
+	nameListKeys = []string{"aliases", "deps", "platforms", "set", "shopt"}
+)
+
+// Taskfile returns the given Taskfile in the canonical style:
+//
+//   - The main sections and the keys of the tasks are in the suggested order,
+//     unless moving them would use an anchor before it is declared. Unknown
+//     keys, like the ones holding anchors, come right after the version, and
+//     merge keys come first in tasks.
+//   - The main sections and the tasks are separated by empty lines.
+//   - Strings are only quoted when needed, with single quotes if possible.
+//   - Dependencies and commands that only have a task or cmd key use the
+//     short form, and lists of names like deps and aliases use the flow style.
+//   - The indentation is two spaces.
+func Taskfile(b []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return b, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind == yaml.MappingNode {
+		sortKeys(&doc, root, rootOrder, "version")
+		if tasks := value(root, "tasks"); tasks != nil && tasks.Kind == yaml.MappingNode {
+			for i := 1; i < len(tasks.Content); i += 2 {
+				if task := tasks.Content[i]; task.Kind == yaml.MappingNode {
+					formatTask(&doc, task)
+				}
+			}
+		}
+	}
+	formatScalars(&doc, false)
+
+	// The encoder writes the merge keys with their tag
+	untagMergeKeys(&doc)
+
+	// The encoder quotes the plain scalars of flow sequences that contain a
+	// colon, like task names with a namespace, and folds the folded scalars
+	// again on a single line. They are replaced by placeholders while
+	// encoding, so that they are written as they are.
+	placeholders := newPlaceholders(b)
+	placeholders.protect(&doc, false)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return separateSections(placeholders.restore(buf.String())), nil
+}
+
+func formatTask(doc *yaml.Node, task *yaml.Node) {
+	sortKeys(doc, task, taskOrder, "")
+
+	if deps := value(task, "deps"); deps != nil && deps.Kind == yaml.SequenceNode {
+		for i, dep := range deps.Content {
+			deps.Content[i] = shortForm(dep, "task")
+		}
+	}
+	if cmds := value(task, "cmds"); cmds != nil && cmds.Kind == yaml.SequenceNode {
+		for i, cmd := range cmds.Content {
+			cmds.Content[i] = shortForm(cmd, "cmd")
+		}
+	}
+	if cmd := value(task, "cmd"); cmd != nil {
+		setValue(task, "cmd", shortForm(cmd, "cmd"))
+	}
+
+	for _, key := range nameListKeys {
+		list := value(task, key)
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		if isNameList(list) {
+			list.Style |= yaml.FlowStyle
+		} else {
+			list.Style &^= yaml.FlowStyle
+		}
+	}
+}
+
+// sortKeys sorts the keys of a mapping in the given order. The unknown keys
+// keep their original order, after the given key or first if it is empty. The
+// mapping is left as is if the sorted keys would use an anchor before it is
+// declared.
+func sortKeys(doc, mapping *yaml.Node, order []string, unknownAfter string) {
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		pairs = append(pairs, pair{mapping.Content[i], mapping.Content[i+1]})
+	}
+	rank := func(key string) int {
+		if i := slices.Index(order, key); i >= 0 {
+			return 2*i + 2
+		}
+		return 2*slices.Index(order, unknownAfter) + 3
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		return rank(a.key.Value) - rank(b.key.Value)
+	})
+
+	original := slices.Clone(mapping.Content)
+	mapping.Content = mapping.Content[:0]
+	for _, p := range pairs {
+		mapping.Content = append(mapping.Content, p.key, p.value)
+	}
+	if !anchorsInOrder(doc, map[string]bool{}) {
+		mapping.Content = original
+	}
+}
+
+// anchorsInOrder tells whether every alias of the node comes after the anchor
+// it refers to.
+func anchorsInOrder(n *yaml.Node, anchors map[string]bool) bool {
+	if n.Kind == yaml.AliasNode && !anchors[n.Value] {
+		return false
+	}
+	if n.Anchor != "" {
+		anchors[n.Anchor] = true
+	}
+	for _, child := range n.Content {
+		if !anchorsInOrder(child, anchors) {
+			return false
+		}
+	}
+	return true
+}
+
+func untagMergeKeys(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i < len(n.Content); i += 2 {
+			if key := n.Content[i]; key.Tag == "!!merge" && key.Style&yaml.TaggedStyle == 0 {
+				key.Tag = ""
+			}
+		}
+	}
+	for _, child := range n.Content {
+		untagMergeKeys(child)
+	}
+}
+
+// shortForm returns the scalar form of a mapping that only has the given key,
+// like a dependency that only has a task. Other nodes are returned as is.
+func shortForm(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode || n.Anchor != "" || len(n.Content) != 2 {
+		return n
+	}
+	k, v := n.Content[0], n.Content[1]
+	if k.Value != key || v.Kind != yaml.ScalarNode || v.ShortTag() != "!!str" || v.Anchor != "" {
+		return n
+	}
+	short := *v
+	short.HeadComment = joinComments(n.HeadComment, k.HeadComment, v.HeadComment)
+	short.LineComment = joinComments(n.LineComment, k.LineComment, v.LineComment)
+	short.FootComment = joinComments(v.FootComment, k.FootComment, n.FootComment)
+	return &short
+}
+
+// isNameList tells whether a sequence only holds scalars without comments,
+// which can be written in flow style.
+func isNameList(seq *yaml.Node) bool {
+	for _, item := range seq.Content {
+		if item.Kind != yaml.ScalarNode || item.Value == "" || item.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return false
+		}
+		if item.HeadComment != "" || item.LineComment != "" || item.FootComment != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// formatScalars removes the quotes of the strings that don't need them, and
+// uses single quotes for the others when possible.
+func formatScalars(n *yaml.Node, flow bool) {
+	flow = flow || n.Style&yaml.FlowStyle != 0
+	if n.Kind == yaml.ScalarNode && n.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 && n.ShortTag() == "!!str" {
+		switch {
+		case isPlain(n.Value, flow):
+			n.Style = 0
+		case !strings.ContainsAny(n.Value, "'\n") && isPrintable(n.Value):
+			n.Style = yaml.SingleQuotedStyle
+		}
+	}
+	for _, child := range n.Content {
+		formatScalars(child, flow)
+	}
+}
+
+// isPlain tells whether a string is read back as is when written without
+// quotes.
+func isPlain(s string, flow bool) bool {
+	if s == "" || strings.ContainsAny(s, "\n\t") {
+		return false
+	}
+	src := s
+	if flow {
+		src = "[" + s + "]"
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil || len(doc.Content) == 0 {
+		return false
+	}
+	n := doc.Content[0]
+	if flow {
+		if n.Kind != yaml.SequenceNode || len(n.Content) != 1 {
+			return false
+		}
+		n = n.Content[0]
+	}
+	return n.Kind == yaml.ScalarNode && n.Style == 0 && n.ShortTag() == "!!str" && n.Value == s
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholders replaces scalars by unique plain strings while encoding, and
+// then by their source.
+type placeholders struct {
+	prefix string
+	src    []string
+	// values are the plain scalars replaced, by placeholder
+	values map[string]string
+	// blocks are the lines of the folded scalars replaced, without their
+	// indentation, by placeholder
+	blocks map[string][]string
+}
+
+func newPlaceholders(src []byte) *placeholders {
+	prefix := "taskfmt"
+	for bytes.Contains(src, []byte(prefix)) {
+		prefix += "x"
+	}
+	return &placeholders{
+		prefix: prefix,
+		src:    strings.Split(string(src), "\n"),
+		values: map[string]string{},
+		blocks: map[string][]string{},
+	}
+}
+
+func (p *placeholders) next() string {
+	return fmt.Sprintf("%s-%d-", p.prefix, len(p.values)+len(p.blocks))
+}
+
+// protect replaces the plain scalars of the flow collections and the folded
+// scalars of the node.
+func (p *placeholders) protect(n *yaml.Node, flow bool) {
+	flow = flow || n.Style&yaml.FlowStyle != 0
+	switch {
+	case n.Kind != yaml.ScalarNode:
+	case flow && n.Style == 0 && n.ShortTag() == "!!str" && isPlain(n.Value, true):
+		placeholder := p.next()
+		p.values[placeholder] = n.Value
+		n.Value = placeholder
+	case n.Style&yaml.FoldedStyle != 0 && !strings.HasSuffix(n.Value, "\n\n"):
+		if lines := p.foldedLines(n); lines != nil {
+			// The encoder writes literal scalars as they are, while it adds
+			// an empty line after some folded ones. The header is changed
+			// back when restoring the lines.
+			placeholder := p.next()
+			p.blocks[placeholder] = lines
+			n.Value = placeholder + n.Value[len(strings.TrimRight(n.Value, "\n")):]
+			n.Style = yaml.LiteralStyle
+		}
+	}
+	for _, child := range n.Content {
+		p.protect(child, flow)
+	}
+}
+
+// foldedLines returns the source lines of the content of a folded scalar,
+// without their common indentation, or nil if it has an explicit indentation.
+func (p *placeholders) foldedLines(n *yaml.Node) []string {
+	if n.Line < 1 || n.Line > len(p.src) {
+		return nil
+	}
+	header := p.src[n.Line-1]
+	if i := strings.LastIndex(header, ">"); i < 0 || strings.ContainsAny(header[i:], "123456789") {
+		return nil
+	}
+	var lines []string
+	for _, line := range p.src[n.Line:] {
+		if strings.TrimSpace(line) != "" && indent(line) <= indent(header) {
+			break
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	common := indent(lines[0])
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = line[min(common, indent(line)):]
+		}
+	}
+	return lines
+}
+
+func (p *placeholders) restore(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		block, ok := p.blocks[strings.TrimSpace(line)]
+		if !ok || len(out) == 0 {
+			out = append(out, line)
+			continue
+		}
+		header := out[len(out)-1]
+		if i := strings.LastIndex(header, "|"); i >= 0 {
+			out[len(out)-1] = header[:i] + ">" + header[i+1:]
+		}
+		for _, blockLine := range block {
+			if blockLine == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, line[:indent(line)]+blockLine)
+			}
+		}
+	}
+	s = strings.Join(out, "\n")
+
+	if len(p.values) == 0 {
+		return s
+	}
+	oldnew := make([]string, 0, len(p.values)*2)
+	for placeholder, v := range p.values {
+		oldnew = append(oldnew, placeholder, v)
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// separateSections adds an empty line before the main sections and the tasks
+// of an encoded Taskfile, and before the comments that precede them.
+func separateSections(s string) []byte {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	inTasks, firstKey, firstTask := false, true, true
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		isKey := trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "-")
+		separate := false
+		switch {
+		case isKey && indent(line) == 0:
+			separate = !firstKey
+			firstKey = false
+			inTasks = strings.HasPrefix(line, "tasks:")
+			firstTask = true
+		case isKey && inTasks && indent(line) == 2:
+			separate = !firstTask
+			firstTask = false
+		}
+		if separate {
+			// Keep the comments that precede the line right above it
+			i := len(out)
+			for i > 0 && strings.HasPrefix(strings.TrimSpace(out[i-1]), "#") && indent(out[i-1]) == indent(line) {
+				i--
+			}
+			if i > 0 && out[i-1] != "" {
+				out = slices.Insert(out, i, "")
+			}
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, "\n"))
+}
+
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// value returns the value of the given key of a mapping, or nil.
+func value(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setValue(mapping *yaml.Node, key string, v *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = v
+		}
+	}
+}
+
+func joinComments(comments ...string) string {
+	var nonEmpty []string
+	for _, c := range comments {
+		if c != "" {
+			nonEmpty = append(nonEmpty, c)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
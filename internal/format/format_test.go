@@ -0,0 +1,258 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/format"
+)
+
+func TestTaskfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "sections",
+			in: `tasks:
+  a: echo a
+  b: echo b
+vars:
+  FOO: bar
+version: "3"
+`,
+			want: `version: '3'
+
+vars:
+  FOO: bar
+
+tasks:
+  a: echo a
+
+  b: echo b
+`,
+		},
+		{
+			name: "task keys",
+			in: `version: '3'
+tasks:
+  build:
+    cmds:
+      - go build
+    sources: ['**/*.go']
+    deps: [generate]
+    desc: Builds
+`,
+			want: `version: '3'
+
+tasks:
+  build:
+    desc: Builds
+    deps: [generate]
+    sources: ['**/*.go']
+    cmds:
+      - go build
+`,
+		},
+		{
+			name: "quotes",
+			in: `version: '3'
+tasks:
+  default:
+    desc: "Says hello"
+    cmds:
+      - "{{.NAME}}"
+      - "echo {{.NAME}}"
+      - "echo 'hi'"
+      - 'echo "hi"'
+      - "true"
+`,
+			want: `version: '3'
+
+tasks:
+  default:
+    desc: Says hello
+    cmds:
+      - '{{.NAME}}'
+      - echo {{.NAME}}
+      - echo 'hi'
+      - echo "hi"
+      - 'true'
+`,
+		},
+		{
+			name: "short forms",
+			in: `version: '3'
+tasks:
+  default:
+    deps:
+      - task: ns:a
+      - task: b
+        vars: {X: 1}
+    cmds:
+      - cmd: echo hi
+      - task: a
+    aliases:
+      - d
+`,
+			want: `version: '3'
+
+tasks:
+  default:
+    aliases: [d]
+    deps:
+      - ns:a
+      - task: b
+        vars: {X: 1}
+    cmds:
+      - echo hi
+      - task: a
+`,
+		},
+		{
+			name: "flow names with namespaces",
+			in: `version: '3'
+tasks:
+  default:
+    deps: [ns:a, 'ns:b']
+`,
+			want: `version: '3'
+
+tasks:
+  default:
+    deps: [ns:a, ns:b]
+`,
+		},
+		{
+			name: "comments",
+			in: `# yaml-language-server: $schema=https://taskfile.dev/schema.json
+version: '3'
+tasks:
+  # Builds the project
+  build:
+    cmds:
+      - go build # fast
+    desc: Builds
+  # Tests the project
+  test: go test
+`,
+			want: `# yaml-language-server: $schema=https://taskfile.dev/schema.json
+version: '3'
+
+tasks:
+  # Builds the project
+  build:
+    desc: Builds
+    cmds:
+      - go build # fast
+
+  # Tests the project
+  test: go test
+`,
+		},
+		{
+			name: "anchors",
+			in: `version: '3'
+x-common: &common
+  silent: true
+tasks:
+  build:
+    cmds: [go build]
+    <<: *common
+    vars: &vars
+      FOO: bar
+    env: *vars
+`,
+			want: `version: '3'
+
+x-common: &common
+  silent: true
+
+tasks:
+  build:
+    <<: *common
+    vars: &vars
+      FOO: bar
+    env: *vars
+    cmds: [go build]
+`,
+		},
+		{
+			name: "anchor used before its declaration once sorted",
+			in: `version: '3'
+tasks:
+  build:
+    env: &vars
+      FOO: bar
+    vars: *vars
+`,
+			want: `version: '3'
+
+tasks:
+  build:
+    env: &vars
+      FOO: bar
+    vars: *vars
+`,
+		},
+		{
+			name: "block scalars",
+			in: `version: '3'
+tasks:
+  build:
+    cmds:
+      - |
+        go build
+        go vet
+      - >-
+        echo one
+          two
+        three
+    summary: >
+      Builds the
+      project
+`,
+			want: `version: '3'
+
+tasks:
+  build:
+    summary: >
+      Builds the
+      project
+    cmds:
+      - |
+        go build
+        go vet
+      - >-
+        echo one
+          two
+        three
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := format.Taskfile([]byte(test.in))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, string(got))
+
+			again, err := format.Taskfile(got)
+			require.NoError(t, err)
+			assert.Equal(t, string(got), string(again), "formatting is not idempotent")
+		})
+	}
+}
+
+func TestTaskfileInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := format.Taskfile([]byte("version: '3'\ntasks: [\n"))
+	require.Error(t, err)
+}
@@ -19,8 +19,13 @@ var (
 	cursorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true) // cyan bold
 	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true) // green bold
 	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))            // gray
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))            // red
 )
 
+// Validator returns an error telling why a value entered by the user isn't
+// valid
+type Validator func(value string) error
+
 // Prompter handles interactive variable prompting
 type Prompter struct {
 	Stdin  io.Reader
@@ -28,9 +33,11 @@ type Prompter struct {
 	Stderr io.Writer
 }
 
-// Text prompts the user for a text value
-func (p *Prompter) Text(varName string) (string, error) {
-	m := newTextModel(varName)
+// Text prompts the user for a text value. The type of the value is shown next
+// to the name of the variable when not empty. The value is validated as it is
+// typed and can only be entered once valid. validate may be nil.
+func (p *Prompter) Text(varName, varType string, validate Validator) (string, error) {
+	m := newTextModel(varName, varType, validate)
 
 	prog := tea.NewProgram(m,
 		tea.WithInput(p.Stdin),
@@ -77,33 +84,42 @@ func (p *Prompter) Select(varName string, options []string) (string, error) {
 }
 
 // Prompt prompts for a variable value, using Select if enum is provided, Text otherwise
-func (p *Prompter) Prompt(varName string, enum []string) (string, error) {
+func (p *Prompter) Prompt(varName string, enum []string, varType string, validate Validator) (string, error) {
 	if len(enum) > 0 {
 		return p.Select(varName, enum)
 	}
-	return p.Text(varName)
+	return p.Text(varName, varType, validate)
 }
 
 // textModel is the Bubble Tea model for text input
 type textModel struct {
 	varName   string
+	varType   string
+	validate  Validator
 	textInput textinput.Model
 	value     string
+	err       error
 	cancelled bool
 	done      bool
 }
 
-func newTextModel(varName string) textModel {
+func newTextModel(varName, varType string, validate Validator) textModel {
 	ti := textinput.New()
 	ti.Placeholder = ""
 	ti.CharLimit = 256
 	ti.SetWidth(40)
 	ti.Focus()
 
-	return textModel{
+	m := textModel{
 		varName:   varName,
+		varType:   varType,
+		validate:  validate,
 		textInput: ti,
 	}
+	if validate != nil {
+		m.err = validate("")
+	}
+	return m
 }
 
 func (m textModel) Init() tea.Cmd {
@@ -119,6 +135,9 @@ func (m textModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.done = true
 			return m, tea.Quit
 		case "enter":
+			if m.err != nil {
+				return m, nil
+			}
 			m.value = m.textInput.Value()
 			m.done = true
 			return m, tea.Quit
@@ -127,6 +146,9 @@ func (m textModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.textInput, cmd = m.textInput.Update(msg)
+	if m.validate != nil {
+		m.err = m.validate(m.textInput.Value())
+	}
 	return m, cmd
 }
 
@@ -135,8 +157,16 @@ func (m textModel) View() tea.View {
 		return tea.NewView("")
 	}
 
-	prompt := promptStyle.Render(fmt.Sprintf("? Enter value for %s: ", m.varName))
-	return tea.NewView(prompt + m.textInput.View() + "\n")
+	name := m.varName
+	if m.varType != "" {
+		name = fmt.Sprintf("%s (%s)", m.varName, m.varType)
+	}
+	prompt := promptStyle.Render(fmt.Sprintf("? Enter value for %s: ", name))
+	view := prompt + m.textInput.View() + "\n"
+	if m.err != nil && m.textInput.Value() != "" {
+		view += errorStyle.Render("  "+m.err.Error()) + "\n"
+	}
+	return tea.NewView(view)
 }
 
 // selectModel is the Bubble Tea model for selection
@@ -0,0 +1,39 @@
+package input
+
+import (
+	"strconv"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextUpdate(t *testing.T) {
+	t.Parallel()
+
+	validate := func(value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	}
+	var m tea.Model = newTextModel("PORT", "int", validate)
+
+	press := func(key tea.Key) {
+		m, _ = m.Update(tea.KeyPressMsg(key))
+	}
+
+	// Invalid values can't be entered
+	press(tea.Key{Code: tea.KeyEnter})
+	assert.False(t, m.(textModel).done)
+	press(tea.Key{Code: 'x', Text: "x"})
+	assert.ErrorContains(t, m.(textModel).err, "invalid syntax")
+	press(tea.Key{Code: tea.KeyEnter})
+	assert.False(t, m.(textModel).done)
+
+	press(tea.Key{Code: tea.KeyBackspace})
+	press(tea.Key{Code: '8', Text: "8"})
+	assert.NoError(t, m.(textModel).err)
+	press(tea.Key{Code: tea.KeyEnter})
+	model := m.(textModel)
+	assert.True(t, model.done)
+	assert.Equal(t, "8", model.value)
+}
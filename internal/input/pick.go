@@ -0,0 +1,278 @@
+package input
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+const (
+	// pickMaxVisible is the number of items of the list shown at once
+	pickMaxVisible = 10
+	// pickMaxSummaryLines is the number of lines of the summary of an item
+	// shown in the preview
+	pickMaxSummaryLines = 6
+)
+
+// PickItem is an entry of the list shown by [Prompter.Pick]
+type PickItem struct {
+	Name    string
+	Aliases []string
+	Desc    string
+	Summary string
+	// Requires are the variables that must be set to run the item, as shown
+	// in the preview
+	Requires []string
+}
+
+// Pick lets the user search the given items and select one of them. Items
+// match the query if it is a substring or a subsequence of their name or
+// aliases, or if suggest returns their name or aliases for it. suggest may be
+// nil. Pick returns the index of the selected item.
+func (p *Prompter) Pick(items []PickItem, suggest func(query string) []string) (int, error) {
+	if len(items) == 0 {
+		return 0, errors.New("no items provided")
+	}
+
+	m := newPickModel(items, suggest)
+
+	prog := tea.NewProgram(m,
+		tea.WithInput(p.Stdin),
+		tea.WithOutput(p.Stderr),
+	)
+
+	result, err := prog.Run()
+	if err != nil {
+		return 0, err
+	}
+
+	model := result.(pickModel)
+	if model.cancelled {
+		return 0, ErrCancelled
+	}
+
+	return model.matches[model.cursor], nil
+}
+
+// pickModel is the Bubble Tea model for picking an item of a list
+type pickModel struct {
+	items     []PickItem
+	suggest   func(string) []string
+	textInput textinput.Model
+	// matches are the indexes of the items that match the query, best first
+	matches   []int
+	cursor    int
+	cancelled bool
+	done      bool
+}
+
+func newPickModel(items []PickItem, suggest func(string) []string) pickModel {
+	ti := textinput.New()
+	ti.Placeholder = "type to search"
+	ti.CharLimit = 256
+	ti.SetWidth(40)
+	ti.Focus()
+
+	m := pickModel{
+		items:     items,
+		suggest:   suggest,
+		textInput: ti,
+	}
+	m.filter()
+	return m
+}
+
+func (m pickModel) Init() tea.Cmd {
+	return tea.Batch(m.textInput.Focus(), textinput.Blink)
+}
+
+func (m pickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch msg.Keystroke() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			m.done = true
+			return m, tea.Quit
+		case "up", "shift+tab", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "tab", "ctrl+n":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	query := m.textInput.Value()
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() != query {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter updates the matches for the current query and moves the cursor to
+// the best match
+func (m *pickModel) filter() {
+	query := strings.ToLower(strings.TrimSpace(m.textInput.Value()))
+	m.cursor = 0
+	m.matches = nil
+	if query == "" {
+		for i := range m.items {
+			m.matches = append(m.matches, i)
+		}
+		return
+	}
+
+	var suggested []string
+	if m.suggest != nil {
+		suggested = m.suggest(query)
+	}
+	scores := map[int]int{}
+	for i, item := range m.items {
+		if score, ok := matchScore(item, query, suggested); ok {
+			m.matches = append(m.matches, i)
+			scores[i] = score
+		}
+	}
+	slices.SortStableFunc(m.matches, func(a, b int) int {
+		return cmp.Compare(scores[a], scores[b])
+	})
+}
+
+// matchScore tells whether the item matches the query, and how well. Lower
+// scores are better.
+func matchScore(item PickItem, query string, suggested []string) (int, bool) {
+	names := append([]string{item.Name}, item.Aliases...)
+	best, ok := 0, false
+	for _, name := range names {
+		var score int
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(lower, query):
+			score = 0
+		case strings.Contains(lower, query):
+			score = 1
+		case isSubsequence(query, lower):
+			score = 2
+		case slices.Contains(suggested, name):
+			score = 3
+		default:
+			continue
+		}
+		if !ok || score < best {
+			best, ok = score, true
+		}
+	}
+	return best, ok
+}
+
+// isSubsequence returns true if the characters of sub appear in s in the same
+// order, not necessarily next to each other
+func isSubsequence(sub, s string) bool {
+	for _, r := range sub {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+func (m pickModel) View() tea.View {
+	if m.done {
+		return tea.NewView("")
+	}
+
+	var b strings.Builder
+
+	b.WriteString(promptStyle.Render("? Pick a task: "))
+	b.WriteString(m.textInput.View())
+	b.WriteString("\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(dimStyle.Render("  no matching tasks"))
+		b.WriteString("\n")
+	}
+
+	// Scroll the list so that the cursor is always visible
+	start := max(0, min(m.cursor-pickMaxVisible/2, len(m.matches)-pickMaxVisible))
+	end := min(start+pickMaxVisible, len(m.matches))
+	width := 0
+	for _, i := range m.matches[start:end] {
+		width = max(width, len(m.items[i].Name))
+	}
+	for n, i := range m.matches[start:end] {
+		item := m.items[i]
+		name := fmt.Sprintf("%-*s", width, item.Name)
+		if start+n == m.cursor {
+			b.WriteString(cursorStyle.Render("❯ "))
+			b.WriteString(selectedStyle.Render(name))
+		} else {
+			b.WriteString("  " + name)
+		}
+		if item.Desc != "" {
+			b.WriteString("  " + dimStyle.Render(item.Desc))
+		}
+		b.WriteString("\n")
+	}
+	if hidden := len(m.matches) - (end - start); hidden > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  (%d more)", hidden)))
+		b.WriteString("\n")
+	}
+
+	if len(m.matches) > 0 {
+		b.WriteString(m.preview(m.items[m.matches[m.cursor]]))
+	}
+
+	b.WriteString(dimStyle.Render("  (type to search, ↑/↓ to move, enter to run, esc to cancel)"))
+
+	return tea.NewView(b.String())
+}
+
+// preview describes the item under the cursor
+func (m pickModel) preview(item PickItem) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(promptStyle.Render("  " + item.Name))
+	if len(item.Aliases) > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf(" (aliases: %s)", strings.Join(item.Aliases, ", "))))
+	}
+	b.WriteString("\n")
+
+	summary := strings.TrimSpace(item.Summary)
+	if summary == "" {
+		summary = item.Desc
+	}
+	if summary != "" {
+		lines := strings.Split(summary, "\n")
+		if len(lines) > pickMaxSummaryLines {
+			lines = append(lines[:pickMaxSummaryLines], "...")
+		}
+		for _, line := range lines {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	if len(item.Requires) > 0 {
+		b.WriteString(fmt.Sprintf("  requires: %s\n", strings.Join(item.Requires, ", ")))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package input
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickFilter(t *testing.T) {
+	t.Parallel()
+
+	items := []PickItem{
+		{Name: "build"},
+		{Name: "docs:build", Aliases: []string{"db"}},
+		{Name: "test"},
+		{Name: "lint"},
+	}
+	suggest := func(query string) []string {
+		if query == "tset" {
+			return []string{"test"}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		query   string
+		matches []int
+	}{
+		{"", []int{0, 1, 2, 3}},
+		{"build", []int{0, 1}},
+		// Prefix matches come first
+		{"d", []int{1, 0}},
+		// Subsequence
+		{"dcb", []int{1}},
+		// Alias
+		{"db", []int{1}},
+		// Suggestion
+		{"tset", []int{2}},
+		{"deploy", nil},
+	}
+	for _, test := range tests {
+		m := newPickModel(items, suggest)
+		m.textInput.SetValue(test.query)
+		m.filter()
+		assert.Equal(t, test.matches, m.matches, test.query)
+	}
+}
+
+func TestPickUpdate(t *testing.T) {
+	t.Parallel()
+
+	items := []PickItem{{Name: "build"}, {Name: "lint"}, {Name: "test"}}
+	var m tea.Model = newPickModel(items, nil)
+
+	press := func(key tea.Key) {
+		m, _ = m.Update(tea.KeyPressMsg(key))
+	}
+	press(tea.Key{Code: 't', Text: "t"})
+	assert.Equal(t, []int{2, 1}, m.(pickModel).matches)
+
+	press(tea.Key{Code: tea.KeyDown})
+	press(tea.Key{Code: tea.KeyDown})
+	assert.Equal(t, 1, m.(pickModel).cursor)
+
+	press(tea.Key{Code: tea.KeyEnter})
+	model := m.(pickModel)
+	assert.True(t, model.done)
+	assert.False(t, model.cancelled)
+	assert.Equal(t, 1, model.matches[model.cursor])
+
+	m = newPickModel(items, nil)
+	press(tea.Key{Code: tea.KeyEscape})
+	assert.True(t, m.(pickModel).cancelled)
+}
@@ -0,0 +1,225 @@
+// Package jsonschema builds JSON Schemas from Go types. Struct fields are
+// mapped to keys like the YAML decoder does, so that the schemas describe the
+// YAML documents the types are decoded from.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Draft is the version of JSON Schema the schemas conform to
+const Draft = "http://json-schema.org/draft-07/schema"
+
+type (
+	// Schema is a JSON Schema. Only the keywords used by Task are supported.
+	Schema struct {
+		Schema      string             `json:"$schema,omitempty"`
+		Ref         string             `json:"$ref,omitempty"`
+		Title       string             `json:"title,omitempty"`
+		Description string             `json:"description,omitempty"`
+		Type        Types              `json:"type,omitempty"`
+		Enum        []any              `json:"enum,omitempty"`
+		Examples    []any              `json:"examples,omitempty"`
+		Pattern     string             `json:"pattern,omitempty"`
+		Default     any                `json:"default,omitempty"`
+		Minimum     *int               `json:"minimum,omitempty"`
+		Items       *Schema            `json:"items,omitempty"`
+		Properties  map[string]*Schema `json:"properties,omitempty"`
+		// AdditionalProperties is either a *Schema or false
+		AdditionalProperties any                `json:"additionalProperties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		OneOf                []*Schema          `json:"oneOf,omitempty"`
+		AnyOf                []*Schema          `json:"anyOf,omitempty"`
+		Not                  *Schema            `json:"not,omitempty"`
+		Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	}
+	// Types are the JSON types allowed by a [Schema]
+	Types []string
+	// Schemer is implemented by the types whose YAML shape isn't described by
+	// their fields, usually because they have a custom UnmarshalYAML method.
+	Schemer interface {
+		JSONSchema(r *Reflector) *Schema
+	}
+	// Reflector builds the schemas of Go types. The types that implement
+	// [Schemer] are added to its definitions and referenced from the other
+	// schemas.
+	Reflector struct {
+		definitions map[string]*Schema
+	}
+)
+
+// Type returns a schema of the given JSON types.
+func Type(types ...string) *Schema {
+	return &Schema{Type: types}
+}
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+var (
+	schemerType         = reflect.TypeFor[Schemer]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+func NewReflector() *Reflector {
+	return &Reflector{definitions: map[string]*Schema{}}
+}
+
+// Reflect returns the schema of the values of type T.
+func Reflect[T any](r *Reflector) *Schema {
+	return r.Reflect(reflect.TypeFor[T]())
+}
+
+// Document returns the schema of the documents of type T, along with the
+// definitions of the types it refers to.
+func Document[T any](title, description string) *Schema {
+	r := NewReflector()
+	s := Reflect[T](r)
+	s.Schema = Draft
+	s.Title = title
+	s.Description = description
+	if len(r.definitions) > 0 {
+		s.Definitions = r.definitions
+	}
+	return s
+}
+
+// Reflect returns the schema of the values of the given type.
+func (r *Reflector) Reflect(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Implements(schemerType) || reflect.PointerTo(t).Implements(schemerType) {
+		name := definitionName(t.Name())
+		if _, ok := r.definitions[name]; !ok {
+			// Reserve the name first, as the schema may refer to itself
+			r.definitions[name] = nil
+			r.definitions[name] = reflect.New(t).Interface().(Schemer).JSONSchema(r)
+		}
+		return &Schema{Ref: "#/definitions/" + name}
+	}
+	if t == durationType {
+		return Type("string")
+	}
+	// The YAML decoder gives the text of scalars to text unmarshalers
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return Type("string", "number")
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Type("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Type("integer")
+	case reflect.Float32, reflect.Float64:
+		return Type("number")
+	case reflect.String:
+		return Type("string")
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: Types{"array"}, Items: r.Reflect(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: r.Reflect(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{
+			Type:                 Types{"object"},
+			Properties:           map[string]*Schema{},
+			AdditionalProperties: false,
+		}
+		r.reflectFields(s, t)
+		return s
+	}
+	// Anything goes, like for interfaces
+	return &Schema{}
+}
+
+// reflectFields adds the properties of the fields of the given struct type to
+// the schema. The keys are the names given by the yaml tags, or the lowercase
+// names of the fields. The desc tag is the description of a property, the enum
+// tag the comma separated values allowed for it or its items, the examples tag
+// the comma separated values suggested for them without restricting them, the
+// pattern tag the regular expression they must match and the default tag its
+// default value, in JSON. The minimum tag is the minimum of numbers.
+func (r *Reflector) reflectFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			r.reflectFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		prop := r.Reflect(field.Type)
+		prop.Description = field.Tag.Get("desc")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			target := prop
+			if prop.Items != nil {
+				target = prop.Items
+			}
+			for value := range strings.SplitSeq(enum, ",") {
+				target.Enum = append(target.Enum, value)
+			}
+		}
+		if examples := field.Tag.Get("examples"); examples != "" {
+			target := prop
+			if prop.Items != nil {
+				target = prop.Items
+			}
+			for value := range strings.SplitSeq(examples, ",") {
+				target.Examples = append(target.Examples, value)
+			}
+		}
+		if pattern := field.Tag.Get("pattern"); pattern != "" {
+			target := prop
+			if prop.Items != nil {
+				target = prop.Items
+			}
+			target.Pattern = pattern
+		}
+		if minimum, err := strconv.Atoi(field.Tag.Get("minimum")); err == nil {
+			prop.Minimum = &minimum
+		}
+		if def := field.Tag.Get("default"); def != "" {
+			if err := json.Unmarshal([]byte(def), &prop.Default); err != nil {
+				prop.Default = def
+			}
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// definitionName converts the name of a Go type to snake case.
+func definitionName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/jsonschema"
+)
+
+type node struct {
+	Name     string
+	Children []*node
+}
+
+func (n *node) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[nodeMapping](r),
+		},
+	}
+}
+
+type nodeMapping struct {
+	Name     string  `desc:"Name of the node."`
+	Children []*node `yaml:"children_nodes"`
+}
+
+type document struct {
+	Root     *node
+	Level    string   `enum:"low,high" default:"low"`
+	Tags     []string `enum:"a,b"`
+	Kind     string   `examples:"x,y"`
+	Name     string   `pattern:"^[a-z]+$"`
+	Count    int      `minimum:"1" default:"2"`
+	Interval time.Duration
+	Ignored  string `yaml:"-"`
+	Meta     map[string]any
+}
+
+func TestDocument(t *testing.T) {
+	t.Parallel()
+
+	got, err := json.Marshal(jsonschema.Document[document]("Title", "Description."))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema",
+		"title": "Title",
+		"description": "Description.",
+		"type": "object",
+		"properties": {
+			"root": {"$ref": "#/definitions/node"},
+			"level": {"type": "string", "enum": ["low", "high"], "default": "low"},
+			"tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}},
+			"kind": {"type": "string", "examples": ["x", "y"]},
+			"name": {"type": "string", "pattern": "^[a-z]+$"},
+			"count": {"type": "integer", "minimum": 1, "default": 2},
+			"interval": {"type": "string"},
+			"meta": {"type": "object", "additionalProperties": {}}
+		},
+		"additionalProperties": false,
+		"definitions": {
+			"node": {
+				"anyOf": [
+					{"type": "string"},
+					{
+						"type": "object",
+						"properties": {
+							"name": {"description": "Name of the node.", "type": "string"},
+							"children_nodes": {"type": "array", "items": {"$ref": "#/definitions/node"}}
+						},
+						"additionalProperties": false
+					}
+				]
+			}
+		}
+	}`, string(got))
+}
@@ -0,0 +1,184 @@
+package lint
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// Severity tells how serious a [Finding] is. Only errors make the validation
+// fail.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rules that a [Finding] can be reported by.
+const (
+	RuleDecode          = "decode"
+	RuleMissingTask     = "missing-task"
+	RuleInvalidTemplate = "invalid-template"
+	RuleUnknownVar      = "unknown-var"
+	RuleEnumValue       = "enum-value"
+	RulePlatforms       = "platforms"
+	RuleUnusedInternal  = "unused-internal"
+	RuleShadowedAlias   = "shadowed-alias"
+	RuleDuplicateAlias  = "duplicate-alias"
+	RuleTaskCycle       = "task-cycle"
+)
+
+type (
+	// Finding is a single problem found in a Taskfile.
+	Finding struct {
+		Severity Severity  `json:"severity"`
+		Rule     string    `json:"rule"`
+		Message  string    `json:"message"`
+		Task     string    `json:"task,omitempty"`
+		Location *Location `json:"location,omitempty"`
+	}
+	// Location describes where a finding is in a Taskfile
+	Location struct {
+		Taskfile string `json:"taskfile"`
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+	}
+)
+
+func (l *Location) String() string {
+	return fmt.Sprintf("%s:%d:%d", filepathext.TryAbsToRel(l.Taskfile), l.Line, l.Column)
+}
+
+// SetupFinding converts an error returned while reading the Taskfile at the
+// given path into a finding, so that it can be reported along with the other
+// ones. Errors without a location are reported at the top of the Taskfile, or
+// without a location if the path isn't known.
+func SetupFinding(path string, err error) Finding {
+	var decodeErr *errors.TaskfileDecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeFinding(decodeErr)
+	}
+	f := Finding{
+		Severity: SeverityError,
+		Rule:     RuleDecode,
+		Message:  err.Error(),
+	}
+	if path != "" {
+		f.Location = &Location{Taskfile: path, Line: 1, Column: 1}
+	}
+	var invalidErr *errors.TaskfileInvalidError
+	var parserErr *yaml.ParserError
+	if errors.As(err, &invalidErr) && errors.As(err, &parserErr) {
+		f.Message = parserErr.Message
+		f.Location = &Location{
+			Taskfile: invalidErr.URI,
+			Line:     max(parserErr.Line, 1),
+			Column:   max(parserErr.Column, 1),
+		}
+		if abs, err := filepath.Abs(invalidErr.URI); err == nil {
+			f.Location.Taskfile = abs
+		}
+	}
+	return f
+}
+
+func decodeFinding(err *errors.TaskfileDecodeError) Finding {
+	message := err.Message
+	if message == "" && err.Err != nil {
+		message = err.Err.Error()
+	}
+	return Finding{
+		Severity: SeverityError,
+		Rule:     RuleDecode,
+		Message:  message,
+		Location: &Location{
+			Taskfile: err.Location,
+			Line:     err.Line,
+			Column:   err.Column,
+		},
+	}
+}
+
+// Sort sorts the findings by location. Findings without a location come
+// first.
+func Sort(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if a.Location == nil || b.Location == nil {
+			switch {
+			case a.Location != nil:
+				return 1
+			case b.Location != nil:
+				return -1
+			}
+			return 0
+		}
+		return cmp.Or(
+			cmp.Compare(a.Location.Taskfile, b.Location.Taskfile),
+			cmp.Compare(a.Location.Line, b.Location.Line),
+			cmp.Compare(a.Location.Column, b.Location.Column),
+		)
+	})
+}
+
+// Count returns the number of errors and warnings in the given findings.
+func Count(findings []Finding) (errs, warnings int) {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	return errs, warnings
+}
+
+// Print prints one line per finding, followed by the number of errors and
+// warnings that were found.
+func Print(l *logger.Logger, w io.Writer, findings []Finding) {
+	for _, f := range findings {
+		if f.Location != nil {
+			l.FOutf(w, logger.Default, "%s: ", f.Location)
+		}
+		if f.Severity == SeverityError {
+			l.FOutf(w, logger.Red, "%s", f.Severity)
+		} else {
+			l.FOutf(w, logger.Yellow, "%s", f.Severity)
+		}
+		l.FOutf(w, logger.Default, ": %s (%s)\n", f.Message, f.Rule)
+	}
+	errs, warnings := Count(findings)
+	if errs == 0 && warnings == 0 {
+		l.FOutf(w, logger.Green, "task: No problems found\n")
+		return
+	}
+	l.FOutf(w, logger.Default, "task: Found %d error(s) and %d warning(s)\n", errs, warnings)
+}
+
+// WriteJSON writes the findings as a JSON document to w.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	errs, warnings := Count(findings)
+	if findings == nil {
+		findings = []Finding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Findings []Finding `json:"findings"`
+		Errors   int       `json:"errors"`
+		Warnings int       `json:"warnings"`
+	}{
+		Findings: findings,
+		Errors:   errs,
+		Warnings: warnings,
+	})
+}
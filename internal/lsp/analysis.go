@@ -0,0 +1,110 @@
+package lsp
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-task/task/v3"
+	"github.com/go-task/task/v3/internal/lint"
+	"github.com/go-task/task/v3/internal/templater"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// analysis is the result of reading a Taskfile and the ones it includes, as
+// done when running it as the entrypoint.
+type analysis struct {
+	// executor is nil if the Taskfile can't be read
+	executor *task.Executor
+	findings []lint.Finding
+}
+
+// analyze reads the Taskfile at the given path from the disk and validates it.
+// Remote Taskfiles are only read from the cache, so that the server never
+// downloads anything or prompts the user.
+func analyze(path string) *analysis {
+	e := task.NewExecutor(
+		task.WithEntrypoint(path),
+		task.WithDir(filepath.Dir(path)),
+		task.WithOffline(true),
+		task.WithStdin(strings.NewReader("")),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	if err := e.Setup(); err != nil {
+		return &analysis{findings: []lint.Finding{lint.SetupFinding(path, err)}}
+	}
+	return &analysis{executor: e, findings: e.Validate()}
+}
+
+// task returns the task called by the given name, or nil if there is none.
+func (a *analysis) task(name string) *ast.Task {
+	if a.executor == nil || name == "" {
+		return nil
+	}
+	t, err := a.executor.GetTask(&task.Call{Task: name})
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// enclosingTask returns the task of the given Taskfile declared last before
+// the given line, which is 1-based.
+func (a *analysis) enclosingTask(path string, line int) *ast.Task {
+	if a.executor == nil {
+		return nil
+	}
+	var found *ast.Task
+	for t := range a.executor.Taskfile.Tasks.Values(nil) {
+		if t.Location == nil || !samePath(t.Location.Taskfile, path) || t.Location.Line > line {
+			continue
+		}
+		if found == nil || t.Location.Line > found.Location.Line {
+			found = t
+		}
+	}
+	return found
+}
+
+// taskAt returns the task whose name is declared at the given line of the
+// Taskfile, which is 1-based.
+func (a *analysis) taskAt(path string, line int) *ast.Task {
+	t := a.enclosingTask(path, line)
+	if t == nil || t.Location.Line != line {
+		return nil
+	}
+	return t
+}
+
+// varNames returns the names of the variables available to the templates of
+// the given task, which may be nil.
+func (a *analysis) varNames(t *ast.Task) []string {
+	names := templater.SpecialVars()
+	if a.executor == nil {
+		return names
+	}
+	for _, vars := range []*ast.Vars{a.executor.Taskfile.Env, a.executor.Taskfile.Vars} {
+		for name := range vars.Keys() {
+			names = append(names, name)
+		}
+	}
+	if t == nil {
+		return names
+	}
+	for _, vars := range []*ast.Vars{t.IncludeVars, t.IncludedTaskfileVars, t.Env, t.Vars} {
+		for name := range vars.Keys() {
+			names = append(names, name)
+		}
+	}
+	if t.Requires != nil {
+		for _, v := range t.Requires.Vars {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+func samePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
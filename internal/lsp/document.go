@@ -0,0 +1,181 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	// inlineDepsRegex matches the text before an item of a flow sequence of
+	// dependencies, like "deps: [build, "
+	inlineDepsRegex = regexp.MustCompile(`\bdeps:\s*\[[^\]]*$`)
+	// varPrefixRegex matches the beginning of a variable inside a template
+	varPrefixRegex = regexp.MustCompile(`\.([A-Za-z0-9_]*)$`)
+)
+
+// document is a Taskfile opened in the editor. Its text is kept up to date on
+// every change, while its analysis is only updated when it is saved.
+type document struct {
+	uri      string
+	path     string
+	lines    []string
+	analysis *analysis
+}
+
+func newDocument(uri, path, text string) *document {
+	d := &document{uri: uri, path: path}
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.lines = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+func (d *document) line(n int) string {
+	if n < 0 || n >= len(d.lines) {
+		return ""
+	}
+	return d.lines[n]
+}
+
+// wordAt returns the task name or variable under the given position, and
+// where it starts and ends in the line, in bytes.
+func (d *document) wordAt(pos Position) (word string, start, end int) {
+	line := d.line(pos.Line)
+	offset := byteOffset(line, pos.Character)
+	start, end = offset, offset
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(line[:start])
+		if !isWordRune(r) {
+			break
+		}
+		start -= size
+	}
+	for end < len(line) {
+		r, size := utf8.DecodeRuneInString(line[end:])
+		if !isWordRune(r) {
+			break
+		}
+		end += size
+	}
+	return line[start:end], start, end
+}
+
+// isTaskRef tells whether the word that starts at the given byte offset of the
+// line is the name of a task called with "task:" or listed in "deps".
+func (d *document) isTaskRef(n, start int) bool {
+	line := d.line(n)
+	before := strings.TrimRight(line[:start], " \t\"'")
+	switch {
+	case strings.HasSuffix(before, "task:"):
+		return true
+	case inlineDepsRegex.MatchString(before):
+		return true
+	case strings.TrimSpace(before) == "-":
+		return d.parentKey(n, indent(line)) == "deps"
+	}
+	return false
+}
+
+// parentKey returns the key of the mapping that holds the sequence item at the
+// given line and indentation.
+func (d *document) parentKey(n, itemIndent int) string {
+	for n--; n >= 0; n-- {
+		line := d.lines[n]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || indent(line) > itemIndent {
+			continue
+		}
+		if indent(line) == itemIndent && strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		key, _, _ := strings.Cut(trimmed, ":")
+		return strings.TrimPrefix(key, "- ")
+	}
+	return ""
+}
+
+// varPrefix returns the part of the name of the variable typed before the
+// given position, if it is inside a template.
+func (d *document) varPrefix(pos Position) (string, bool) {
+	line := d.line(pos.Line)
+	before := line[:byteOffset(line, pos.Character)]
+	open := strings.LastIndex(before, "{{")
+	if open < 0 || strings.LastIndex(before, "}}") > open {
+		return "", false
+	}
+	m := varPrefixRegex.FindStringSubmatch(before[open:])
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// rangeOf returns the range of the given bytes of a line.
+func (d *document) rangeOf(n, start, end int) Range {
+	line := d.line(n)
+	return Range{
+		Start: Position{Line: n, Character: character(line, start)},
+		End:   Position{Line: n, Character: character(line, end)},
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:*", r)
+}
+
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// byteOffset converts a character of a position, counted in UTF-16 code units
+// as required by the protocol, into an offset in bytes.
+func byteOffset(line string, char int) int {
+	units := 0
+	for i, r := range line {
+		if units >= char {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// character converts an offset in bytes into a character of a position.
+func character(line string, offset int) int {
+	offset = min(offset, len(line))
+	units := 0
+	for _, r := range line[:offset] {
+		units += utf16.RuneLen(r)
+	}
+	return units
+}
+
+// uriToPath returns the path of a file URI.
+func uriToPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path), true
+}
+
+// pathToURI returns the file URI of an absolute path.
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
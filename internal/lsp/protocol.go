@@ -0,0 +1,152 @@
+package lsp
+
+import "encoding/json"
+
+// The subset of the Language Server Protocol used by the server. See
+// https://microsoft.github.io/language-server-protocol/specification for the
+// meaning of each field.
+
+const (
+	// textDocumentSyncFull means that clients send the whole document on
+	// every change.
+	textDocumentSyncFull = 1
+
+	severityError   = 1
+	severityWarning = 2
+
+	completionItemKindVariable = 6
+	completionItemKindFunction = 3
+
+	markupKindMarkdown = "markdown"
+
+	// Error codes of JSON-RPC responses
+	codeParseError     = -32700
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+)
+
+type (
+	// request is a JSON-RPC request received from the client. Notifications
+	// are requests without an ID.
+	request struct {
+		ID     json.RawMessage `json:"id,omitempty"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params,omitempty"`
+	}
+	// response answers a request. Exactly one of Result and Error is set, and
+	// a null result is sent as such.
+	response struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  *any            `json:"result,omitempty"`
+		Error   *responseError  `json:"error,omitempty"`
+	}
+	responseError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	// notification is a message sent to the client that expects no answer
+	notification struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+		Params  any    `json:"params"`
+	}
+
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+	Range struct {
+		Start Position `json:"start"`
+		End   Position `json:"end"`
+	}
+	Location struct {
+		URI   string `json:"uri"`
+		Range Range  `json:"range"`
+	}
+
+	Diagnostic struct {
+		Range    Range  `json:"range"`
+		Severity int    `json:"severity"`
+		Code     string `json:"code,omitempty"`
+		Source   string `json:"source"`
+		Message  string `json:"message"`
+	}
+	publishDiagnosticsParams struct {
+		URI         string       `json:"uri"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}
+
+	textDocumentIdentifier struct {
+		URI string `json:"uri"`
+	}
+	textDocumentItem struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	}
+	didOpenParams struct {
+		TextDocument textDocumentItem `json:"textDocument"`
+	}
+	didChangeParams struct {
+		TextDocument   textDocumentIdentifier `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	didSaveParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+		Text         *string                `json:"text,omitempty"`
+	}
+	didCloseParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+	}
+	textDocumentPositionParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+		Position     Position               `json:"position"`
+	}
+
+	MarkupContent struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	}
+	Hover struct {
+		Contents MarkupContent `json:"contents"`
+		Range    *Range        `json:"range,omitempty"`
+	}
+
+	CompletionItem struct {
+		Label  string `json:"label"`
+		Kind   int    `json:"kind"`
+		Detail string `json:"detail,omitempty"`
+	}
+	CompletionList struct {
+		IsIncomplete bool             `json:"isIncomplete"`
+		Items        []CompletionItem `json:"items"`
+	}
+
+	initializeResult struct {
+		Capabilities serverCapabilities `json:"capabilities"`
+		ServerInfo   serverInfo         `json:"serverInfo"`
+	}
+	serverCapabilities struct {
+		TextDocumentSync   textDocumentSyncOptions `json:"textDocumentSync"`
+		DefinitionProvider bool                    `json:"definitionProvider"`
+		HoverProvider      bool                    `json:"hoverProvider"`
+		CompletionProvider completionOptions       `json:"completionProvider"`
+	}
+	textDocumentSyncOptions struct {
+		OpenClose bool        `json:"openClose"`
+		Change    int         `json:"change"`
+		Save      saveOptions `json:"save"`
+	}
+	saveOptions struct {
+		IncludeText bool `json:"includeText"`
+	}
+	completionOptions struct {
+		TriggerCharacters []string `json:"triggerCharacters"`
+	}
+	serverInfo struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+)
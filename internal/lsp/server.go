@@ -0,0 +1,400 @@
+// Package lsp implements a Language Server Protocol server for Taskfiles. It
+// reports the problems found by the validation of a Taskfile when it is opened
+// or saved, and offers go to definition, hover and completion for the tasks
+// and variables it refers to.
+package lsp
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/lint"
+	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/internal/version"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Server answers the requests of an editor for the Taskfiles it opens. Each
+// Taskfile is read as if it was the entrypoint, along with the Taskfiles it
+// includes.
+type Server struct {
+	conn *conn
+	docs map[string]*document
+	// published are the URIs that got diagnostics from the analysis of each
+	// document, so that they can be cleared once fixed
+	published map[string][]string
+	shutdown  bool
+}
+
+// NewServer returns a [Server] that reads requests from r and writes its
+// answers to w, usually stdin and stdout.
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn:      newConn(r, w),
+		docs:      map[string]*document{},
+		published: map[string][]string{},
+	}
+}
+
+// Run handles the requests until the editor asks the server to exit or
+// closes its input.
+func (s *Server) Run() error {
+	for {
+		body, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := s.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("task: The language server exited before being shut down")
+			}
+			return nil
+		}
+		if err := s.handle(&req); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a request or notification. Only errors writing to the
+// editor are returned, the other ones are sent as answers.
+func (s *Server) handle(req *request) error {
+	if req.ID == nil {
+		return s.handleNotification(req)
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch req.Method {
+	case "initialize":
+		result = s.initialize()
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			result = s.definition(params)
+		}
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			result = s.hover(params)
+		}
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			result = s.completion(params)
+		}
+	default:
+		return s.replyError(req.ID, codeMethodNotFound, fmt.Sprintf("method %q is not supported", req.Method))
+	}
+	if err != nil {
+		return s.replyError(req.ID, codeInvalidParams, err.Error())
+	}
+	return s.conn.write(&response{JSONRPC: "2.0", ID: req.ID, Result: &result})
+}
+
+// handleNotification handles a message that expects no answer. Notifications
+// that aren't supported, like "initialized", and the ones with invalid
+// parameters are ignored.
+func (s *Server) handleNotification(req *request) error {
+	switch req.Method {
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(req.Params, &params); err == nil {
+			return s.didOpen(params)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params, &params); err == nil {
+			s.didChange(params)
+		}
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := json.Unmarshal(req.Params, &params); err == nil {
+			return s.didSave(params)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(req.Params, &params); err == nil {
+			return s.didClose(params)
+		}
+	}
+	return nil
+}
+
+func (s *Server) replyError(id json.RawMessage, code int, message string) error {
+	return s.conn.write(&response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &responseError{Code: code, Message: message},
+	})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.conn.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) initialize() *initializeResult {
+	return &initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      saveOptions{IncludeText: true},
+			},
+			DefinitionProvider: true,
+			HoverProvider:      true,
+			CompletionProvider: completionOptions{
+				TriggerCharacters: []string{".", ":", " "},
+			},
+		},
+		ServerInfo: serverInfo{
+			Name:    "task",
+			Version: version.GetVersion(),
+		},
+	}
+}
+
+func (s *Server) didOpen(params didOpenParams) error {
+	path, ok := uriToPath(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+	d := newDocument(params.TextDocument.URI, path, params.TextDocument.Text)
+	s.docs[d.uri] = d
+	return s.analyze(d)
+}
+
+func (s *Server) didChange(params didChangeParams) {
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok || len(params.ContentChanges) == 0 {
+		return
+	}
+	d.setText(params.ContentChanges[len(params.ContentChanges)-1].Text)
+}
+
+func (s *Server) didSave(params didSaveParams) error {
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	if params.Text != nil {
+		d.setText(*params.Text)
+	}
+	return s.analyze(d)
+}
+
+func (s *Server) didClose(params didCloseParams) error {
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	delete(s.docs, d.uri)
+	defer delete(s.published, d.uri)
+	return s.publish(d, nil)
+}
+
+// analyze reads the Taskfile of the document and publishes the problems found
+// in it and in the Taskfiles it includes.
+func (s *Server) analyze(d *document) error {
+	d.analysis = analyze(d.path)
+	return s.publish(d, d.analysis.findings)
+}
+
+// publish sends the diagnostics of the given findings, grouped by Taskfile,
+// and clears the ones previously sent for the document that were fixed since.
+func (s *Server) publish(d *document, findings []lint.Finding) error {
+	diagnostics := map[string][]Diagnostic{}
+	for _, f := range findings {
+		uri, r := d.uri, Range{}
+		if f.Location != nil {
+			uri, r = s.findingRange(d, f.Location)
+		}
+		diagnostics[uri] = append(diagnostics[uri], Diagnostic{
+			Range:    r,
+			Severity: severity(f.Severity),
+			Code:     f.Rule,
+			Source:   "task",
+			Message:  f.Message,
+		})
+	}
+	for _, uri := range s.published[d.uri] {
+		if _, ok := diagnostics[uri]; !ok {
+			diagnostics[uri] = []Diagnostic{}
+		}
+	}
+	// The document itself is always published, so that the editor knows it
+	// was checked
+	if _, ok := diagnostics[d.uri]; !ok {
+		diagnostics[d.uri] = []Diagnostic{}
+	}
+
+	uris := make([]string, 0, len(diagnostics))
+	for uri := range diagnostics {
+		uris = append(uris, uri)
+	}
+	slices.Sort(uris)
+	s.published[d.uri] = uris
+	for _, uri := range uris {
+		err := s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: diagnostics[uri],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findingRange returns where the finding is. In open documents, it goes until
+// the end of the line.
+func (s *Server) findingRange(d *document, l *lint.Location) (string, Range) {
+	uri := pathToURI(l.Taskfile)
+	pos := Position{Line: max(l.Line-1, 0), Character: max(l.Column-1, 0)}
+	open, ok := s.docs[uri]
+	if samePath(l.Taskfile, d.path) {
+		uri, open, ok = d.uri, d, true
+	}
+	if !ok {
+		return uri, Range{Start: pos, End: pos}
+	}
+	line := open.line(pos.Line)
+	start := min(pos.Character, len(line))
+	end := max(start, len(strings.TrimRight(line, " \t")))
+	return uri, open.rangeOf(pos.Line, start, end)
+}
+
+func (s *Server) definition(params textDocumentPositionParams) *Location {
+	d, t, _ := s.taskRefAt(params)
+	if t == nil || t.Location == nil || t.Location.Taskfile == "" {
+		return nil
+	}
+	pos := Position{Line: max(t.Location.Line-1, 0), Character: max(t.Location.Column-1, 0)}
+	uri := pathToURI(t.Location.Taskfile)
+	if samePath(t.Location.Taskfile, d.path) {
+		uri = d.uri
+	}
+	return &Location{URI: uri, Range: Range{Start: pos, End: pos}}
+}
+
+func (s *Server) hover(params textDocumentPositionParams) *Hover {
+	d, t, r := s.taskRefAt(params)
+	if t == nil {
+		// Not a reference, but maybe the declaration of the task
+		var ok bool
+		if d, ok = s.docs[params.TextDocument.URI]; !ok || d.analysis == nil {
+			return nil
+		}
+		word, start, end := d.wordAt(params.Position)
+		t = d.analysis.taskAt(d.path, params.Position.Line+1)
+		if t == nil || word == "" {
+			return nil
+		}
+		r = d.rangeOf(params.Position.Line, start, end)
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: markupKindMarkdown, Value: describe(t)},
+		Range:    &r,
+	}
+}
+
+// taskRefAt returns the task called at the given position of a document, and
+// the range of its name.
+func (s *Server) taskRefAt(params textDocumentPositionParams) (*document, *ast.Task, Range) {
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok || d.analysis == nil {
+		return d, nil, Range{}
+	}
+	word, start, end := d.wordAt(params.Position)
+	if word == "" || !d.isTaskRef(params.Position.Line, start) {
+		return d, nil, Range{}
+	}
+	return d, d.analysis.task(word), d.rangeOf(params.Position.Line, start, end)
+}
+
+// describe returns the Markdown shown when hovering a task.
+func describe(t *ast.Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", t.Task)
+	if len(t.Aliases) > 0 {
+		fmt.Fprintf(&b, " (aliases: %s)", strings.Join(t.Aliases, ", "))
+	}
+	if t.Desc != "" {
+		fmt.Fprintf(&b, "\n\n%s", t.Desc)
+	}
+	if summary := strings.TrimSpace(t.Summary); summary != "" {
+		fmt.Fprintf(&b, "\n\n%s", summary)
+	}
+	if t.Requires != nil && len(t.Requires.Vars) > 0 {
+		names := make([]string, len(t.Requires.Vars))
+		for i, v := range t.Requires.Vars {
+			names[i] = "`" + v.Name + "`"
+		}
+		fmt.Fprintf(&b, "\n\nRequires: %s", strings.Join(names, ", "))
+	}
+	return b.String()
+}
+
+func (s *Server) completion(params textDocumentPositionParams) *CompletionList {
+	list := &CompletionList{Items: []CompletionItem{}}
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok || d.analysis == nil {
+		return list
+	}
+
+	if _, ok := d.varPrefix(params.Position); ok {
+		t := d.analysis.enclosingTask(d.path, params.Position.Line+1)
+		names := d.analysis.varNames(t)
+		slices.Sort(names)
+		for _, name := range slices.Compact(names) {
+			list.Items = append(list.Items, CompletionItem{
+				Label: name,
+				Kind:  completionItemKindVariable,
+			})
+		}
+		return list
+	}
+
+	line := d.line(params.Position.Line)
+	_, start, _ := d.wordAt(params.Position)
+	if start > byteOffset(line, params.Position.Character) || !d.isTaskRef(params.Position.Line, start) {
+		return list
+	}
+	if d.analysis.executor == nil {
+		return list
+	}
+	for t := range d.analysis.executor.Taskfile.Tasks.Values(sort.AlphaNumericWithRootTasksFirst) {
+		list.Items = append(list.Items, CompletionItem{
+			Label:  t.Task,
+			Kind:   completionItemKindFunction,
+			Detail: cmp.Or(t.Desc, t.Summary),
+		})
+	}
+	return list
+}
+
+func severity(s lint.Severity) int {
+	if s == lint.SeverityError {
+		return severityError
+	}
+	return severityWarning
+}
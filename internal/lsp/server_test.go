@@ -0,0 +1,276 @@
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// client talks to a [Server] running in the background, like an editor would
+type client struct {
+	t        *testing.T
+	conn     *conn
+	messages chan map[string]json.RawMessage
+	nextID   int
+	done     chan error
+}
+
+func startServer(t *testing.T) *client {
+	t.Helper()
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &client{
+		t:        t,
+		conn:     newConn(clientIn, clientOut),
+		messages: make(chan map[string]json.RawMessage, 100),
+		done:     make(chan error, 1),
+	}
+	go func() {
+		c.done <- NewServer(serverIn, serverOut).Run()
+		serverOut.Close()
+	}()
+	go func() {
+		defer close(c.messages)
+		for {
+			body, err := c.conn.read()
+			if err != nil {
+				return
+			}
+			var msg map[string]json.RawMessage
+			if err := json.Unmarshal(body, &msg); err == nil {
+				c.messages <- msg
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		clientOut.Close()
+	})
+
+	c.request("initialize", map[string]any{})
+	c.notify("initialized", map[string]any{})
+	return c
+}
+
+func (c *client) notify(method string, params any) {
+	c.t.Helper()
+	require.NoError(c.t, c.conn.write(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	}))
+}
+
+// request sends a request and returns its result, skipping the notifications
+// received meanwhile.
+func (c *client) request(method string, params any) json.RawMessage {
+	c.t.Helper()
+	c.nextID++
+	require.NoError(c.t, c.conn.write(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      c.nextID,
+		"method":  method,
+		"params":  params,
+	}))
+	for {
+		msg := c.next()
+		if string(msg["id"]) != jsonInt(c.nextID) {
+			continue
+		}
+		require.Nil(c.t, msg["error"], "request %s failed: %s", method, msg["error"])
+		return msg["result"]
+	}
+}
+
+// diagnostics returns the next diagnostics published for the given URI.
+func (c *client) diagnostics(uri string) []Diagnostic {
+	c.t.Helper()
+	for {
+		msg := c.next()
+		if string(msg["method"]) != `"textDocument/publishDiagnostics"` {
+			continue
+		}
+		var params publishDiagnosticsParams
+		require.NoError(c.t, json.Unmarshal(msg["params"], &params))
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+}
+
+func (c *client) next() map[string]json.RawMessage {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.messages:
+		require.True(c.t, ok, "server closed the connection")
+		return msg
+	case <-time.After(10 * time.Second):
+		require.FailNow(c.t, "timed out waiting for the server")
+		return nil
+	}
+}
+
+func jsonInt(n int) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+// open opens the Taskfile of the testdata directory at the given path, and
+// returns its URI and lines.
+func (c *client) open(path string) (string, []string) {
+	c.t.Helper()
+	path, err := filepath.Abs(filepath.Join("testdata", path))
+	require.NoError(c.t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(c.t, err)
+	uri := pathToURI(path)
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri":        uri,
+			"languageId": "yaml",
+			"version":    1,
+			"text":       string(b),
+		},
+	})
+	return uri, strings.Split(string(b), "\n")
+}
+
+// positionOf returns the position of the end of the given text in the first
+// line that contains it.
+func positionOf(t *testing.T, lines []string, text string) map[string]any {
+	t.Helper()
+	for n, line := range lines {
+		if i := strings.Index(line, text); i >= 0 {
+			return map[string]any{"line": n, "character": i + len(text)}
+		}
+	}
+	require.FailNow(t, "text not found", text)
+	return nil
+}
+
+func positionParams(t *testing.T, uri string, lines []string, text string) map[string]any {
+	t.Helper()
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     positionOf(t, lines, text),
+	}
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	c := startServer(t)
+
+	uri, _ := c.open("Taskfile.yml")
+	diagnostics := c.diagnostics(uri)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "missing-task", diagnostics[0].Code)
+	assert.Equal(t, severityError, diagnostics[0].Severity)
+	assert.Equal(t, Range{
+		Start: Position{Line: 27, Character: 8},
+		End:   Position{Line: 27, Character: 21},
+	}, diagnostics[0].Range)
+
+	uri, _ = c.open("invalid/Taskfile.yml")
+	diagnostics = c.diagnostics(uri)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "decode", diagnostics[0].Code)
+	assert.Equal(t, 4, diagnostics[0].Range.Start.Line)
+	assert.Contains(t, diagnostics[0].Message, "cannot unmarshal")
+}
+
+func TestServerDefinition(t *testing.T) {
+	t.Parallel()
+
+	c := startServer(t)
+	uri, lines := c.open("Taskfile.yml")
+	c.diagnostics(uri)
+
+	tests := []struct {
+		name     string
+		text     string
+		wantFile string
+		wantLine int
+	}{
+		{name: "included task in deps", text: "deps: [lib:gen", wantFile: "lib/Taskfile.yml", wantLine: 3},
+		{name: "task in cmds", text: "task: dep", wantFile: "Taskfile.yml", wantLine: 20},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var location Location
+			result := c.request("textDocument/definition", positionParams(t, uri, lines, test.text))
+			require.NoError(t, json.Unmarshal(result, &location))
+			want, err := filepath.Abs(filepath.Join("testdata", test.wantFile))
+			require.NoError(t, err)
+			assert.Equal(t, pathToURI(want), location.URI)
+			assert.Equal(t, Position{Line: test.wantLine, Character: 2}, location.Range.Start)
+		})
+	}
+
+	t.Run("not a task", func(t *testing.T) {
+		result := c.request("textDocument/definition", positionParams(t, uri, lines, "echo {{.GREE"))
+		assert.Equal(t, "null", string(result))
+	})
+}
+
+func TestServerHover(t *testing.T) {
+	t.Parallel()
+
+	c := startServer(t)
+	uri, lines := c.open("Taskfile.yml")
+	c.diagnostics(uri)
+
+	var hover Hover
+	result := c.request("textDocument/hover", positionParams(t, uri, lines, "task: dep"))
+	require.NoError(t, json.Unmarshal(result, &hover))
+	assert.Equal(t, "**deploy** (aliases: d)\n\nDeploys the project\n\nRequires: `ENV`", hover.Contents.Value)
+
+	result = c.request("textDocument/hover", positionParams(t, uri, lines, "  bui"))
+	require.NoError(t, json.Unmarshal(result, &hover))
+	assert.Equal(t, "**build**\n\nBuilds the project\n\nBuilds the project.\n\nThe generated code is built too.", hover.Contents.Value)
+}
+
+func TestServerCompletion(t *testing.T) {
+	t.Parallel()
+
+	c := startServer(t)
+	uri, lines := c.open("Taskfile.yml")
+	c.diagnostics(uri)
+
+	labels := func(text string) []string {
+		var list CompletionList
+		result := c.request("textDocument/completion", positionParams(t, uri, lines, text))
+		require.NoError(t, json.Unmarshal(result, &list))
+		labels := make([]string, len(list.Items))
+		for i, item := range list.Items {
+			labels[i] = item.Label
+		}
+		return labels
+	}
+
+	assert.Equal(t, []string{"build", "deploy", "lib:generate"}, labels("task: "))
+	assert.Subset(t, labels("echo {{."), []string{"GREETING", "TASK"})
+	assert.NotContains(t, labels("echo {{."), "ENV", "ENV is only required by deploy")
+	assert.Subset(t, labels("echo {{.E"), []string{"ENV", "GREETING"})
+	assert.Empty(t, labels("desc: Builds"))
+}
+
+func TestServerShutdown(t *testing.T) {
+	t.Parallel()
+
+	c := startServer(t)
+	c.request("shutdown", nil)
+	c.notify("exit", nil)
+	select {
+	case err := <-c.done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the server to exit")
+	}
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/events"
+)
+
+// JUnit is an [events.Listener] that records every task that was called as a
+// JUnit test case so that CI systems can show them in their test reports.
+type JUnit struct {
+	mu       sync.Mutex
+	start    time.Time
+	cases    []junitTestCase
+	upToDate map[string]bool
+	output   map[string][]string
+}
+
+// NewJUnit creates a new, empty [JUnit] report.
+func NewJUnit() *JUnit {
+	return &JUnit{
+		start:    time.Now(),
+		upToDate: map[string]bool{},
+		output:   map[string][]string{},
+	}
+}
+
+// CapturesOutput implements [events.OutputCapturer] so that the output of
+// failed commands can be included in the report.
+func (j *JUnit) CapturesOutput() bool {
+	return true
+}
+
+func (j *JUnit) Handle(ev events.Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	switch ev.Type {
+	case events.TaskUpToDate:
+		j.upToDate[ev.Task] = true
+	case events.TaskSkipped:
+		if ev.Reason == events.ReasonDuplicate {
+			return
+		}
+		j.cases = append(j.cases, junitTestCase{
+			Name:      ev.Task,
+			ClassName: "task",
+			Time:      seconds(0),
+			Skipped:   &junitMessage{Message: fmt.Sprintf("skipped (%s)", ev.Reason)},
+		})
+	case events.CommandFinished:
+		if ev.Output != "" {
+			j.output[ev.Task] = append(j.output[ev.Task], ev.Output)
+		}
+	case events.TaskFinished:
+		tc := junitTestCase{
+			Name:      ev.Task,
+			ClassName: "task",
+			Time:      seconds(ev.Duration),
+		}
+		switch {
+		case ev.Err != nil:
+			err := &errors.TaskRunError{TaskName: ev.Task, Err: ev.Err}
+			tc.Failure = &junitMessage{
+				Message: err.Error(),
+				Type:    fmt.Sprintf("exit code %d", err.TaskExitCode()),
+				Body:    xmlSafe(strings.Join(j.output[ev.Task], "")),
+			}
+		case j.upToDate[ev.Task]:
+			tc.Skipped = &junitMessage{Message: "up to date"}
+		}
+		delete(j.upToDate, ev.Task)
+		delete(j.output, ev.Task)
+		j.cases = append(j.cases, tc)
+	}
+}
+
+// WriteXML writes the report as a JUnit XML document to w. The given name is
+// used as the name of the test suite.
+func (j *JUnit) WriteXML(w io.Writer, name string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	suite := junitTestSuite{
+		Name:      name,
+		Tests:     len(j.cases),
+		Time:      seconds(time.Since(j.start)),
+		Timestamp: j.start.Format(time.RFC3339),
+		TestCases: j.cases,
+	}
+	for _, tc := range j.cases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+	suites := junitTestSuites{
+		Name:       name,
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Skipped:    suite.Skipped,
+		Time:       suite.Time,
+		TestSuites: []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// ansiEscapes matches the escape sequences used to color terminal output.
+var ansiEscapes = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// xmlSafe removes colors and any other characters that are not allowed in XML
+// documents.
+func xmlSafe(s string) string {
+	s = ansiEscapes.ReplaceAllString(s, "")
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != 0xFFFE && r != 0xFFFF) {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,43 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mvdan.cc/sh/v3/interp"
+
+	"github.com/go-task/task/v3/internal/events"
+	"github.com/go-task/task/v3/internal/report"
+)
+
+func TestJUnit(t *testing.T) {
+	t.Parallel()
+
+	j := report.NewJUnit()
+	for _, ev := range []events.Event{
+		{Type: events.TaskStarted, Task: "generate"},
+		{Type: events.TaskUpToDate, Task: "generate"},
+		{Type: events.TaskFinished, Task: "generate", Duration: 10 * time.Millisecond},
+		{Type: events.TaskSkipped, Task: "lint", Reason: events.ReasonIf},
+		{Type: events.TaskStarted, Task: "build"},
+		{Type: events.TaskFinished, Task: "build", Duration: 1500 * time.Millisecond},
+		{Type: events.TaskStarted, Task: "test"},
+		{Type: events.CommandFinished, Task: "test", Output: "\x1b[31m--- FAIL: TestFoo\n", Err: interp.ExitStatus(1)},
+		{Type: events.TaskFinished, Task: "test", Duration: time.Second, Err: interp.ExitStatus(1)},
+	} {
+		j.Handle(ev)
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, j.WriteXML(&buff, "Taskfile.yml"))
+	out := buff.String()
+
+	assert.Contains(t, out, `<testsuites name="Taskfile.yml" tests="4" failures="1" skipped="2"`)
+	assert.Contains(t, out, `<testcase name="generate" classname="task" time="0.010">`+"\n"+`      <skipped message="up to date"></skipped>`)
+	assert.Contains(t, out, `<testcase name="lint" classname="task" time="0.000">`+"\n"+`      <skipped message="skipped (if)"></skipped>`)
+	assert.Contains(t, out, `<testcase name="build" classname="task" time="1.500"></testcase>`)
+	assert.Contains(t, out, `<failure message="task: Failed to run task &#34;test&#34;: exit status 1" type="exit code 1"><![CDATA[--- FAIL: TestFoo`+"\n"+`]]></failure>`)
+}
@@ -0,0 +1,193 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Ladicle/tabwriter"
+
+	"github.com/go-task/task/v3/internal/events"
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// Status is the outcome of a task in a run.
+type Status string
+
+const (
+	StatusRan             Status = "ran"
+	StatusUpToDate        Status = "up-to-date"
+	StatusSkippedIf       Status = "skipped (if)"
+	StatusSkippedPlatform Status = "skipped (platform)"
+	StatusFailed          Status = "failed"
+)
+
+// precedence decides which status is reported when a task is called more than
+// once in the same run. Higher values win.
+var precedence = map[Status]int{
+	StatusSkippedPlatform: 1,
+	StatusSkippedIf:       2,
+	StatusUpToDate:        3,
+	StatusRan:             4,
+	StatusFailed:          5,
+}
+
+// TaskResult is the summary of every call to a task in a run.
+type TaskResult struct {
+	Task     string        `json:"task"`
+	Status   Status        `json:"status"`
+	Duration time.Duration `json:"-"`
+	Calls    int           `json:"calls"`
+	Commands int           `json:"commands"`
+}
+
+func (r TaskResult) MarshalJSON() ([]byte, error) {
+	type alias TaskResult
+	return json.Marshal(struct {
+		alias
+		DurationMs float64 `json:"duration_ms"`
+	}{
+		alias:      alias(r),
+		DurationMs: float64(r.Duration) / float64(time.Millisecond),
+	})
+}
+
+// Recorder is an [events.Listener] that collects the result of every task run
+// so that a summary can be printed at the end of a run.
+type Recorder struct {
+	mu       sync.Mutex
+	start    time.Time
+	results  map[string]*TaskResult
+	upToDate map[string]bool
+	order    []string
+}
+
+// NewRecorder creates a new, empty [Recorder].
+func NewRecorder() *Recorder {
+	return &Recorder{
+		start:    time.Now(),
+		results:  map[string]*TaskResult{},
+		upToDate: map[string]bool{},
+	}
+}
+
+func (r *Recorder) Handle(ev events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch ev.Type {
+	case events.TaskStarted:
+		r.result(ev.Task).Calls++
+	case events.TaskUpToDate:
+		r.upToDate[ev.Task] = true
+	case events.TaskSkipped:
+		switch ev.Reason {
+		case events.ReasonIf:
+			r.setStatus(ev.Task, StatusSkippedIf)
+		case events.ReasonPlatform:
+			r.setStatus(ev.Task, StatusSkippedPlatform)
+		}
+	case events.CommandFinished:
+		r.result(ev.Task).Commands++
+	case events.TaskFinished:
+		res := r.result(ev.Task)
+		res.Duration += ev.Duration
+		switch {
+		case ev.Err != nil:
+			r.setStatus(ev.Task, StatusFailed)
+		case r.upToDate[ev.Task]:
+			r.setStatus(ev.Task, StatusUpToDate)
+		default:
+			r.setStatus(ev.Task, StatusRan)
+		}
+		delete(r.upToDate, ev.Task)
+	}
+}
+
+func (r *Recorder) result(task string) *TaskResult {
+	res, ok := r.results[task]
+	if !ok {
+		res = &TaskResult{Task: task}
+		r.results[task] = res
+		r.order = append(r.order, task)
+	}
+	return res
+}
+
+func (r *Recorder) setStatus(task string, status Status) {
+	res := r.result(task)
+	if precedence[status] > precedence[res.Status] {
+		res.Status = status
+	}
+}
+
+// Results returns the result of every task that was recorded, in the order in
+// which they were first seen.
+func (r *Recorder) Results() []TaskResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]TaskResult, 0, len(r.order))
+	for _, task := range r.order {
+		results = append(results, *r.results[task])
+	}
+	return results
+}
+
+// PrintTable writes the results as a human-readable table to w.
+func (r *Recorder) PrintTable(l *logger.Logger, w io.Writer) error {
+	results := r.Results()
+	total := time.Since(r.start)
+
+	l.FOutf(w, logger.Default, "task: Run summary:\n")
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	l.FOutf(tw, logger.Default, "TASK\tSTATUS\tDURATION\tCOMMANDS\n")
+	for _, res := range results {
+		l.FOutf(tw, logger.Green, "%s", res.Task)
+		l.FOutf(tw, statusColor(res.Status), "\t%s", res.Status)
+		l.FOutf(tw, logger.Default, "\t%s\t%d\n", formatDuration(res.Duration), res.Commands)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	l.FOutf(w, logger.Default, "task: Total time: %s\n", formatDuration(total))
+	return nil
+}
+
+// WriteJSON writes the results as a JSON document to w.
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	results := r.Results()
+	total := time.Since(r.start)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Tasks           []TaskResult `json:"tasks"`
+		TotalDurationMs float64      `json:"total_duration_ms"`
+	}{
+		Tasks:           results,
+		TotalDurationMs: float64(total) / float64(time.Millisecond),
+	})
+}
+
+func statusColor(status Status) logger.Color {
+	switch status {
+	case StatusRan:
+		return logger.Green
+	case StatusFailed:
+		return logger.Red
+	case StatusUpToDate:
+		return logger.Magenta
+	default:
+		return logger.Yellow
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
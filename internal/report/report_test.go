@@ -0,0 +1,68 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/events"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/internal/report"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	r := report.NewRecorder()
+	for _, ev := range []events.Event{
+		{Type: events.TaskStarted, Task: "ci"},
+		{Type: events.TaskStarted, Task: "generate"},
+		{Type: events.TaskUpToDate, Task: "generate"},
+		{Type: events.TaskFinished, Task: "generate", Duration: 10 * time.Millisecond},
+		{Type: events.TaskSkipped, Task: "windows", Reason: events.ReasonPlatform},
+		{Type: events.TaskStarted, Task: "build"},
+		{Type: events.CommandFinished, Task: "build"},
+		{Type: events.CommandFinished, Task: "build"},
+		{Type: events.TaskFinished, Task: "build", Duration: 2 * time.Second},
+		{Type: events.TaskSkipped, Task: "lint", Reason: events.ReasonIf},
+		{Type: events.TaskStarted, Task: "test"},
+		{Type: events.CommandFinished, Task: "test"},
+		{Type: events.TaskFinished, Task: "test", Duration: time.Second, Err: errors.New("exit status 1")},
+		{Type: events.TaskFinished, Task: "ci", Duration: 3 * time.Second, Err: errors.New("exit status 1")},
+	} {
+		r.Handle(ev)
+	}
+
+	assert.Equal(t, []report.TaskResult{
+		{Task: "ci", Status: report.StatusFailed, Duration: 3 * time.Second, Calls: 1},
+		{Task: "generate", Status: report.StatusUpToDate, Duration: 10 * time.Millisecond, Calls: 1},
+		{Task: "windows", Status: report.StatusSkippedPlatform},
+		{Task: "build", Status: report.StatusRan, Duration: 2 * time.Second, Calls: 1, Commands: 2},
+		{Task: "lint", Status: report.StatusSkippedIf},
+		{Task: "test", Status: report.StatusFailed, Duration: time.Second, Calls: 1, Commands: 1},
+	}, r.Results())
+
+	var table bytes.Buffer
+	require.NoError(t, r.PrintTable(&logger.Logger{}, &table))
+	assert.Contains(t, table.String(), "build     ran                 2s        2")
+
+	var buff bytes.Buffer
+	require.NoError(t, r.WriteJSON(&buff))
+	var doc struct {
+		Tasks []map[string]any `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &doc))
+	require.Len(t, doc.Tasks, 6)
+	assert.Equal(t, map[string]any{
+		"task":        "build",
+		"status":      "ran",
+		"calls":       float64(1),
+		"commands":    float64(2),
+		"duration_ms": float64(2000),
+	}, doc.Tasks[3])
+}
@@ -0,0 +1,158 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-task/task/v3/internal/events"
+)
+
+// Trace is an [events.Listener] that records tasks, commands and the time
+// spent waiting for the concurrency limit as spans in the Chrome Trace Event
+// Format, which can be opened in Perfetto or chrome://tracing. Each lane the
+// events were emitted from is shown as a separate thread.
+type Trace struct {
+	mu     sync.Mutex
+	events []traceEvent
+	lanes  map[int]bool
+}
+
+// NewTrace creates a new, empty [Trace].
+func NewTrace() *Trace {
+	return &Trace{lanes: map[int]bool{}}
+}
+
+func (tr *Trace) Handle(ev events.Event) {
+	var te traceEvent
+	switch ev.Type {
+	case events.TaskFinished:
+		te = tr.span("task", ev.Task, ev)
+	case events.CommandFinished:
+		te = tr.span("command", ev.Command, ev)
+		te.Args["task"] = ev.Task
+	case events.DeferredRan:
+		te = tr.span("deferred", ev.Command, ev)
+		te.Args["task"] = ev.Task
+	case events.ConcurrencyWaited:
+		te = tr.span("wait", "waiting for concurrency limit", ev)
+		te.Args["task"] = ev.Task
+	case events.TaskSkipped:
+		te = tr.instant(fmt.Sprintf("%s skipped (%s)", ev.Task, ev.Reason), ev)
+	case events.TaskUpToDate:
+		te = tr.instant(fmt.Sprintf("%s up to date", ev.Task), ev)
+	case events.PreconditionFailed:
+		te = tr.instant(fmt.Sprintf("%s precondition failed", ev.Task), ev)
+	default:
+		return
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.events = append(tr.events, te)
+	tr.lanes[ev.Lane] = true
+}
+
+func (tr *Trace) span(category, name string, ev events.Event) traceEvent {
+	args := map[string]any{}
+	if ev.ExitCode != nil {
+		args["exit_code"] = *ev.ExitCode
+	}
+	if ev.Err != nil {
+		args["error"] = ev.Err.Error()
+	}
+	return traceEvent{
+		Name:     name,
+		Category: category,
+		Phase:    "X",
+		start:    ev.Time.Add(-ev.Duration),
+		Dur:      microseconds(ev.Duration),
+		Tid:      ev.Lane,
+		Args:     args,
+	}
+}
+
+func (tr *Trace) instant(name string, ev events.Event) traceEvent {
+	return traceEvent{
+		Name:     name,
+		Category: "task",
+		Phase:    "i",
+		Scope:    "t",
+		start:    ev.Time,
+		Tid:      ev.Lane,
+	}
+}
+
+// WriteJSON writes the trace to w as a JSON document in the Chrome Trace Event
+// Format. Timestamps are relative to the start of the first span.
+func (tr *Trace) WriteJSON(w io.Writer) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var origin time.Time
+	for _, te := range tr.events {
+		if origin.IsZero() || te.start.Before(origin) {
+			origin = te.start
+		}
+	}
+
+	traceEvents := make([]traceEvent, 0, len(tr.events)+len(tr.lanes)+1)
+	traceEvents = append(traceEvents, traceEvent{
+		Name:  "process_name",
+		Phase: "M",
+		Args:  map[string]any{"name": "task"},
+	})
+	lanes := make([]int, 0, len(tr.lanes))
+	for lane := range tr.lanes {
+		lanes = append(lanes, lane)
+	}
+	slices.Sort(lanes)
+	for _, lane := range lanes {
+		name := "main"
+		if lane > 0 {
+			name = fmt.Sprintf("lane %d", lane)
+		}
+		traceEvents = append(traceEvents, traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			Tid:   lane,
+			Args:  map[string]any{"name": name},
+		})
+	}
+	for _, te := range tr.events {
+		ts := microseconds(te.start.Sub(origin))
+		te.Ts = &ts
+		traceEvents = append(traceEvents, te)
+	}
+
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{
+		TraceEvents:     traceEvents,
+		DisplayTimeUnit: "ms",
+	})
+}
+
+// traceEvent is a single event of the Chrome Trace Event Format.
+type traceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Scope    string         `json:"s,omitempty"`
+	Ts       *float64       `json:"ts,omitempty"`
+	Dur      float64        `json:"dur,omitempty"`
+	Pid      int            `json:"pid"`
+	Tid      int            `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+
+	start time.Time
+}
+
+func microseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
@@ -0,0 +1,48 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/events"
+	"github.com/go-task/task/v3/internal/report"
+)
+
+func TestTrace(t *testing.T) {
+	t.Parallel()
+
+	exitCode := 0
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tr := report.NewTrace()
+	for _, ev := range []events.Event{
+		{Type: events.TaskStarted, Time: start, Task: "build"},
+		{Type: events.ConcurrencyWaited, Time: start.Add(2 * time.Millisecond), Task: "lint", Duration: 2 * time.Millisecond, Lane: 1},
+		{Type: events.CommandFinished, Time: start.Add(3 * time.Millisecond), Task: "build", Command: "go build", Duration: 2 * time.Millisecond, ExitCode: &exitCode},
+		{Type: events.TaskUpToDate, Time: start.Add(4 * time.Millisecond), Task: "lint", Lane: 1},
+		{Type: events.TaskFinished, Time: start.Add(5 * time.Millisecond), Task: "build", Duration: 5 * time.Millisecond, ExitCode: &exitCode},
+	} {
+		tr.Handle(ev)
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, tr.WriteJSON(&buff))
+
+	var got struct {
+		TraceEvents []map[string]any `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &got))
+	assert.Equal(t, []map[string]any{
+		{"name": "process_name", "ph": "M", "pid": 0.0, "tid": 0.0, "args": map[string]any{"name": "task"}},
+		{"name": "thread_name", "ph": "M", "pid": 0.0, "tid": 0.0, "args": map[string]any{"name": "main"}},
+		{"name": "thread_name", "ph": "M", "pid": 0.0, "tid": 1.0, "args": map[string]any{"name": "lane 1"}},
+		{"name": "waiting for concurrency limit", "cat": "wait", "ph": "X", "ts": 0.0, "dur": 2000.0, "pid": 0.0, "tid": 1.0, "args": map[string]any{"task": "lint"}},
+		{"name": "go build", "cat": "command", "ph": "X", "ts": 1000.0, "dur": 2000.0, "pid": 0.0, "tid": 0.0, "args": map[string]any{"task": "build", "exit_code": 0.0}},
+		{"name": "lint up to date", "cat": "task", "ph": "i", "s": "t", "ts": 4000.0, "pid": 0.0, "tid": 1.0},
+		{"name": "build", "cat": "task", "ph": "X", "ts": 0.0, "dur": 5000.0, "pid": 0.0, "tid": 0.0, "args": map[string]any{"exit_code": 0.0}},
+	}, got.TraceEvents)
+}
@@ -247,16 +247,32 @@ func printTaskRequires(l *logger.Logger, t *ast.Task) {
 	l.Outf(logger.Default, "  vars:\n")
 
 	for _, v := range t.Requires.Vars {
+		// Simple required variable
+		if len(v.Enum) == 0 && v.Type == "" && v.Pattern == "" && v.Min == "" && v.Max == "" && v.Default == nil {
+			l.Outf(logger.Yellow, "    - %s\n", v.Name)
+			continue
+		}
+
+		l.Outf(logger.Yellow, "    - %s:\n", v.Name)
+		for _, field := range []struct{ name, value string }{
+			{"type", v.Type},
+			{"pattern", v.Pattern},
+			{"min", v.Min},
+			{"max", v.Max},
+		} {
+			if field.value != "" {
+				l.Outf(logger.Yellow, "        %s: %s\n", field.name, field.value)
+			}
+		}
+		if v.Default != nil {
+			l.Outf(logger.Yellow, "        default: %s\n", *v.Default)
+		}
 		// If the variable has enum constraints, format accordingly
 		if len(v.Enum) > 0 {
-			l.Outf(logger.Yellow, "    - %s:\n", v.Name)
 			l.Outf(logger.Yellow, "        enum:\n")
 			for _, enumValue := range v.Enum {
 				l.Outf(logger.Yellow, "          - %s\n", enumValue)
 			}
-		} else {
-			// Simple required variable
-			l.Outf(logger.Yellow, "    - %s\n", v.Name)
 		}
 	}
 }
@@ -0,0 +1,224 @@
+package taskgraph
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Formats that a [ast.TaskGraph] can be written in.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+type (
+	// Graph is the JSON representation of a [ast.TaskGraph].
+	Graph struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}
+	// Node describes a single task of the graph
+	Node struct {
+		Name      string    `json:"name"`
+		Namespace string    `json:"namespace,omitempty"`
+		Desc      string    `json:"desc,omitempty"`
+		Internal  bool      `json:"internal,omitempty"`
+		Location  *Location `json:"location,omitempty"`
+	}
+	// Edge describes a task calling another one, either as a dep or from a cmd
+	Edge struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Type string `json:"type"`
+	}
+	// Location describes a task's location in a taskfile
+	Location struct {
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+		Taskfile string `json:"taskfile"`
+	}
+)
+
+// New converts the given task graph into its JSON representation. Nodes and
+// edges are sorted by task name.
+func New(tg *ast.TaskGraph) (*Graph, error) {
+	adjacencyMap, err := tg.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Graph{
+		Nodes: make([]Node, 0, len(adjacencyMap)),
+		Edges: []Edge{},
+	}
+	for name, edges := range adjacencyMap {
+		t, err := tg.Vertex(name)
+		if err != nil {
+			return nil, err
+		}
+		node := Node{
+			Name:      t.Task,
+			Namespace: t.Namespace,
+			Desc:      t.Desc,
+			Internal:  t.Internal,
+		}
+		if t.Location != nil {
+			node.Location = &Location{
+				Line:     t.Location.Line,
+				Column:   t.Location.Column,
+				Taskfile: t.Location.Taskfile,
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+
+		for to, edge := range edges {
+			var kind string
+			if ref, ok := edge.Properties.Data.(*ast.TaskReference); ok {
+				kind = ref.Kind
+			}
+			g.Edges = append(g.Edges, Edge{From: name, To: to, Type: kind})
+		}
+	}
+
+	slices.SortFunc(g.Nodes, func(a, b Node) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+	return g, nil
+}
+
+// Write writes the given task graph to w in the given format.
+func Write(w io.Writer, tg *ast.TaskGraph, format string) error {
+	g, err := New(tg)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case FormatDOT:
+		return g.WriteDOT(w)
+	case FormatMermaid:
+		return g.WriteMermaid(w)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	default:
+		return fmt.Errorf("task: unsupported graph format %q", format)
+	}
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Tasks of included
+// Taskfiles are grouped in a cluster per namespace, and edges of cmds are
+// dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph tasks {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for namespace, nodes := range g.namespaces() {
+		indent := "  "
+		if namespace != "" {
+			fmt.Fprintf(&b, "  subgraph %s {\n", strconv.Quote("cluster_"+namespace))
+			fmt.Fprintf(&b, "    label=%s;\n", strconv.Quote(namespace))
+			indent = "    "
+		}
+		for _, node := range nodes {
+			fmt.Fprintf(&b, "%s%s", indent, strconv.Quote(node.Name))
+			if node.Location != nil {
+				fmt.Fprintf(&b, " [tooltip=%s]", strconv.Quote(node.Location.String()))
+			}
+			b.WriteString(";\n")
+		}
+		if namespace != "" {
+			b.WriteString("  }\n")
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s", strconv.Quote(edge.From), strconv.Quote(edge.To))
+		if edge.Type == ast.TaskEdgeCmd {
+			b.WriteString(" [style=dashed]")
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart. Tasks of included
+// Taskfiles are grouped in a subgraph per namespace, and edges of cmds are
+// dotted.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.Name] = fmt.Sprintf("t%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for namespace, nodes := range g.namespaces() {
+		indent := "  "
+		if namespace != "" {
+			fmt.Fprintf(&b, "  subgraph %s [%s]\n", "ns_"+ids[nodes[0].Name], mermaidLabel(namespace))
+			indent = "    "
+		}
+		for _, node := range nodes {
+			fmt.Fprintf(&b, "%s%s[%s]\n", indent, ids[node.Name], mermaidLabel(node.Name))
+		}
+		if namespace != "" {
+			b.WriteString("  end\n")
+		}
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Type == ast.TaskEdgeCmd {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// namespaces returns an iterator over the nodes of the graph grouped by
+// namespace. Tasks of the root Taskfile come first.
+func (g *Graph) namespaces() iter.Seq2[string, []Node] {
+	return func(yield func(string, []Node) bool) {
+		var namespaces []string
+		nodes := map[string][]Node{}
+		for _, node := range g.Nodes {
+			if _, ok := nodes[node.Namespace]; !ok {
+				namespaces = append(namespaces, node.Namespace)
+			}
+			nodes[node.Namespace] = append(nodes[node.Namespace], node)
+		}
+		slices.Sort(namespaces)
+		for _, namespace := range namespaces {
+			if !yield(namespace, nodes[namespace]) {
+				return
+			}
+		}
+	}
+}
+
+func (l *Location) String() string {
+	return fmt.Sprintf("%s:%d:%d", filepathext.TryAbsToRel(l.Taskfile), l.Line, l.Column)
+}
+
+// mermaidLabel quotes the given text so that it can be used as the label of a
+// Mermaid node.
+func mermaidLabel(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,143 @@
+package templater
+
+import (
+	"slices"
+	"strings"
+	"text/template/parse"
+
+	"github.com/go-task/template"
+)
+
+// specialVars are the variables that Task sets by itself, in addition to the
+// ones from the environment and the Taskfiles.
+var specialVars = []string{
+	"TASK", "TASK_DIR", "TASKFILE", "TASKFILE_DIR", "ROOT_TASKFILE", "ROOT_DIR",
+	"USER_WORKING_DIR", "TASK_VERSION", "TASK_EXE", "ALIAS", "MATCH",
+	"CLI_ARGS", "CLI_ARGS_LIST", "CLI_FORCE", "CLI_SILENT", "CLI_VERBOSE",
+	"CLI_OFFLINE", "CLI_ASSUME_YES",
+	"ITEM", "KEY", "EXIT_CODE", "CHECKSUM", "TIMESTAMP", "WATCH_CHANGED_FILES",
+}
+
+// SpecialVars returns the names of the variables that Task sets by itself.
+func SpecialVars() []string {
+	return slices.Clone(specialVars)
+}
+
+// IsSpecialVar tells whether Task sets the variable with the given name by
+// itself.
+func IsSpecialVar(name string) bool {
+	return slices.Contains(specialVars, name)
+}
+
+// ReferencedVars returns the names of the variables that the given template
+// reads from its data, like FOO in {{.FOO}} or {{$.FOO.bar}}. Fields that are
+// read inside of a range or with block, where the dot no longer refers to the
+// variables, are left out. So are the variables that the template expects to
+// be unset at times, because they are only checked by an if or with action or
+// given to a function like default. An error is returned if the template is
+// invalid.
+func ReferencedVars(s string) ([]string, error) {
+	if !strings.Contains(s, "{{") {
+		return nil, nil
+	}
+	tpl, err := template.New("").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if tpl.Tree == nil {
+		return nil, nil
+	}
+	var names []string
+	walkVars(tpl.Root, true, &names)
+	return names, nil
+}
+
+func walkVars(node parse.Node, dotIsRoot bool, names *[]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkVars(child, dotIsRoot, names)
+		}
+	case *parse.ActionNode:
+		walkVars(n.Pipe, dotIsRoot, names)
+	case *parse.PipeNode:
+		if n == nil || handlesMissingVars(n) {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkVars(cmd, dotIsRoot, names)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkVars(arg, dotIsRoot, names)
+		}
+	case *parse.ChainNode:
+		walkVars(n.Node, dotIsRoot, names)
+	case *parse.FieldNode:
+		if dotIsRoot {
+			*names = append(*names, n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			*names = append(*names, n.Ident[1])
+		}
+	case *parse.IfNode:
+		if !isVarCheck(n.Pipe) {
+			walkVars(n.Pipe, dotIsRoot, names)
+		}
+		walkVars(n.List, dotIsRoot, names)
+		walkVars(n.ElseList, dotIsRoot, names)
+	case *parse.RangeNode:
+		walkVars(n.Pipe, dotIsRoot, names)
+		walkVars(n.List, false, names)
+		walkVars(n.ElseList, dotIsRoot, names)
+	case *parse.WithNode:
+		if !isVarCheck(n.Pipe) {
+			walkVars(n.Pipe, dotIsRoot, names)
+		}
+		walkVars(n.List, false, names)
+		walkVars(n.ElseList, dotIsRoot, names)
+	case *parse.TemplateNode:
+		walkVars(n.Pipe, dotIsRoot, names)
+	}
+}
+
+// handlesMissingVars returns true if the pipeline calls a function that is
+// meant to be given variables that may be unset.
+func handlesMissingVars(pipe *parse.PipeNode) bool {
+	for _, cmd := range pipe.Cmds {
+		if len(cmd.Args) == 0 {
+			continue
+		}
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+			switch ident.Ident {
+			case "default", "coalesce", "empty", "hasKey":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isVarCheck returns true if the pipeline only checks whether a variable is
+// set, like in {{if .FOO}} or {{if not .FOO}}.
+func isVarCheck(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 {
+		return false
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) == 2 {
+		if ident, ok := args[0].(*parse.IdentifierNode); !ok || ident.Ident != "not" {
+			return false
+		}
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return false
+	}
+	_, ok := args[0].(*parse.FieldNode)
+	return ok
+}
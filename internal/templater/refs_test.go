@@ -0,0 +1,39 @@
+package templater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferencedVars(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{"no template", "echo hello", nil},
+		{"fields", "echo {{.FOO}} {{.BAR.baz}}", []string{"FOO", "BAR"}},
+		{"root variable", "{{range .LIST}}{{.name}} {{$.FOO}}{{end}}", []string{"LIST", "FOO"}},
+		{"with", "{{with .FOO}}{{.bar}}{{else}}{{.BAZ}}{{end}}", []string{"BAZ"}},
+		{"if check", "{{if .DEBUG}}-v{{end}}{{if not .QUIET}}{{.LEVEL}}{{end}}", []string{"LEVEL"}},
+		{"if condition", `{{if eq .OS "linux"}}x{{end}}`, []string{"OS"}},
+		{"default", `{{.FOO | default "bar"}} {{default "baz" .BAR}}`, nil},
+		{"functions", `{{.FOO | trim | upper}} {{len .BAR}}`, []string{"FOO", "BAR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReferencedVars(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ReferencedVars("{{.FOO")
+	require.Error(t, err)
+}
@@ -0,0 +1,225 @@
+package watcher
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// IgnoreFiles are the files read in each directory for patterns of files that
+// should not be watched. They use the syntax of .gitignore files, and the
+// patterns of .taskignore take precedence.
+var IgnoreFiles = []string{".gitignore", ".taskignore"}
+
+// Ignorer tells whether files should not be watched, based on the ignore
+// files of their directories and on a list of patterns. The ignore files are
+// read from the top level directory of the git repository, like git does.
+type Ignorer struct {
+	root     string
+	patterns []ignorePattern
+
+	mu    sync.Mutex
+	files map[string][]ignorePattern
+}
+
+// ignorePattern is a line of an ignore file
+type ignorePattern struct {
+	// base are the path elements of the directory of the pattern, relative to
+	// the root of the Ignorer
+	base     []string
+	elems    []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewIgnorer returns an [Ignorer] for the files of the git repository that
+// contains dir, or of dir itself outside a repository. The given patterns use
+// the syntax of .gitignore files and are relative to dir.
+func NewIgnorer(dir string, patterns []string) *Ignorer {
+	i := &Ignorer{
+		root:  gitRoot(dir),
+		files: map[string][]ignorePattern{},
+	}
+	base := i.elems(dir)
+	for _, line := range patterns {
+		if p, ok := parseIgnorePattern(base, line); ok {
+			i.patterns = append(i.patterns, p)
+		}
+	}
+	return i
+}
+
+// gitRoot returns the closest directory that contains dir and has a .git
+// entry, or dir if there is none.
+func gitRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// Ignored returns true if the given path, or one of the directories that
+// contain it, is ignored. Paths outside the root are never ignored.
+func (i *Ignorer) Ignored(path string, isDir bool) bool {
+	elems := i.elems(path)
+	if elems == nil {
+		return false
+	}
+	for n := 1; n <= len(elems); n++ {
+		if i.match(elems[:n], n < len(elems) || isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// elems returns the path elements of path relative to the root, or nil if the
+// path is outside of it.
+func (i *Ignorer) elems(p string) []string {
+	rel, err := filepath.Rel(i.root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	if rel == "." {
+		return []string{}
+	}
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
+
+// match tells whether the given path is ignored by the patterns that apply to
+// it, not considering the directories that contain it. The last matching
+// pattern wins, and deeper ignore files override the ones above them.
+func (i *Ignorer) match(elems []string, isDir bool) bool {
+	ignored := false
+	check := func(patterns []ignorePattern) {
+		for _, p := range patterns {
+			if p.matches(elems, isDir) {
+				ignored = !p.negate
+			}
+		}
+	}
+	check(i.patterns)
+	for n := 0; n < len(elems); n++ {
+		check(i.dirPatterns(elems[:n]))
+	}
+	return ignored
+}
+
+// dirPatterns returns the patterns of the ignore files of the given directory
+func (i *Ignorer) dirPatterns(dir []string) []ignorePattern {
+	key := path.Join(dir...)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if patterns, ok := i.files[key]; ok {
+		return patterns
+	}
+	var patterns []ignorePattern
+	for _, name := range IgnoreFiles {
+		patterns = append(patterns, readIgnoreFile(dir, filepath.Join(i.root, filepath.FromSlash(key), name))...)
+	}
+	i.files[key] = patterns
+	return patterns
+}
+
+func readIgnoreFile(base []string, file string) []ignorePattern {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var patterns []ignorePattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := parseIgnorePattern(base, scanner.Text()); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+func parseIgnorePattern(base []string, line string) (ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+	p := ignorePattern{base: base}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	// Escaped leading characters
+	line = strings.TrimPrefix(line, `\`)
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	// A pattern with a slash in the beginning or middle is relative to the
+	// directory of the ignore file. Otherwise, it matches at any depth.
+	if strings.Contains(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignorePattern{}, false
+	}
+	p.elems = strings.Split(line, "/")
+	return p, true
+}
+
+func (p ignorePattern) matches(elems []string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if len(elems) <= len(p.base) {
+		return false
+	}
+	for n, e := range p.base {
+		if elems[n] != e {
+			return false
+		}
+	}
+	elems = elems[len(p.base):]
+	if !p.anchored {
+		ok, _ := path.Match(p.elems[0], elems[len(elems)-1])
+		return ok
+	}
+	return matchElems(p.elems, elems)
+}
+
+// matchElems matches path elements against pattern elements, where "**"
+// matches any number of elements.
+func matchElems(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				// A trailing "/**" matches everything inside
+				return len(elems) > 0
+			}
+			for n := range len(elems) + 1 {
+				if matchElems(pattern[1:], elems[n:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+			return false
+		}
+		pattern, elems = pattern[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
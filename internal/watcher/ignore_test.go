@@ -0,0 +1,75 @@
+package watcher_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/watcher"
+)
+
+func TestIgnorer(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o755))
+	write(".gitignore", "# build outputs\nnode_modules/\n/dist\n*.log\n!keep.log\ndocs/**/generated\n")
+	write("app/.taskignore", "vendor/\n/tmp/\n")
+	write("app/sub/.gitignore", "*.tmp\n")
+
+	// The Taskfile is in a subdirectory of the repository
+	ignorer := watcher.NewIgnorer(filepath.Join(root, "app"), []string{"/cache", "*.bak"})
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"main.go", false, false},
+		{"node_modules", true, true},
+		{"node_modules", false, false},
+		{"web/node_modules/react/index.js", false, true},
+		{"dist", true, true},
+		{"web/dist", true, false},
+		{"debug.log", false, true},
+		{"logs/keep.log", false, false},
+		{"docs/api/generated/index.md", false, true},
+		{"docs/generated", true, true},
+		{"docs/api/index.md", false, false},
+		{"app/vendor/pkg/a.go", false, true},
+		{"app/tmp", true, true},
+		{"app/sub/tmp", true, false},
+		{"app/sub/a.tmp", false, true},
+		{"app/b.tmp", false, false},
+		{"app/cache/a.go", false, true},
+		{"cache/a.go", false, false},
+		{"app/sub/a.bak", false, true},
+		{"a.bak", false, false},
+	}
+	for _, test := range tests {
+		path := filepath.Join(root, filepath.FromSlash(test.path))
+		assert.Equal(t, test.ignored, ignorer.Ignored(path, test.isDir), test.path)
+	}
+
+	assert.False(t, ignorer.Ignored(filepath.Join(filepath.Dir(root), "debug.log"), false), "outside of the root")
+}
+
+func TestIgnorerWithoutRepository(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".taskignore"), []byte("build\n"), 0o644))
+
+	ignorer := watcher.NewIgnorer(dir, nil)
+	assert.True(t, ignorer.Ignored(filepath.Join(dir, "build", "out"), false))
+	assert.False(t, ignorer.Ignored(filepath.Join(dir, "src", "main.go"), false))
+}
@@ -0,0 +1,189 @@
+package watcher
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Poll is a [Watcher] that lists the watched directories at a regular interval
+// and compares the modification time and size of their files with the
+// previous listing. It works on file systems that don't emit events, like
+// network shares and some container mounts.
+type Poll struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	dirs map[string]map[string]fileState
+
+	events    chan fsnotify.Event
+	errors    chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewPoll returns a [Poll] watcher that lists the watched directories every
+// interval.
+func NewPoll(interval time.Duration) *Poll {
+	w := &Poll{
+		interval: interval,
+		dirs:     map[string]map[string]fileState{},
+		events:   make(chan fsnotify.Event),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *Poll) Add(dir string) error {
+	files, err := listDir(dir)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.dirs[dir]; !ok {
+		w.dirs[dir] = files
+	}
+	return nil
+}
+
+func (w *Poll) Events() <-chan fsnotify.Event {
+	return w.events
+}
+
+func (w *Poll) Errors() <-chan error {
+	return w.errors
+}
+
+func (w *Poll) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+	})
+	return nil
+}
+
+func (w *Poll) run() {
+	defer close(w.errors)
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if !w.poll() {
+				return
+			}
+		}
+	}
+}
+
+// poll lists the watched directories and sends the changes since the last
+// listing. It returns false if the watcher was closed meanwhile.
+func (w *Poll) poll() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for dir, old := range w.dirs {
+		files, err := listDir(dir)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// fsnotify stops watching a directory once it is removed
+			delete(w.dirs, dir)
+		case err != nil:
+			if !w.sendError(err) {
+				return false
+			}
+			continue
+		default:
+			w.dirs[dir] = files
+		}
+
+		for name, state := range files {
+			var op fsnotify.Op
+			oldState, ok := old[name]
+			switch {
+			case !ok:
+				op = fsnotify.Create
+			case !state.modTime.Equal(oldState.modTime) || state.size != oldState.size:
+				op = fsnotify.Write
+			default:
+				continue
+			}
+			if !w.sendEvent(fsnotify.Event{Name: filepath.Join(dir, name), Op: op}) {
+				return false
+			}
+		}
+		for name := range old {
+			if _, ok := files[name]; ok {
+				continue
+			}
+			if !w.sendEvent(fsnotify.Event{Name: filepath.Join(dir, name), Op: fsnotify.Remove}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sendEvent sends the event, unless the watcher is closed first.
+func (w *Poll) sendEvent(event fsnotify.Event) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// sendError sends the error, unless the watcher is closed first.
+func (w *Poll) sendError(err error) bool {
+	select {
+	case w.errors <- err:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// listDir returns the state of the files of the given directory, by name.
+// Subdirectories are skipped, as they are not watched recursively.
+func listDir(dir string) (map[string]fileState, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]fileState, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// Removed since the directory was read
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files[entry.Name()] = fileState{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,73 @@
+package watcher_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/internal/watcher"
+)
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o644))
+
+	w := watcher.NewPoll(10 * time.Millisecond)
+	defer w.Close()
+	require.NoError(t, w.Add(dir))
+
+	next := func() fsnotify.Event {
+		t.Helper()
+		select {
+		case event := <-w.Events():
+			return event
+		case err := <-w.Errors():
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no event received")
+		}
+		return fsnotify.Event{}
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte("changed"), 0o644))
+	assert.Equal(t, fsnotify.Event{Name: file, Op: fsnotify.Write}, next())
+
+	created := filepath.Join(dir, "b.txt")
+	require.NoError(t, os.WriteFile(created, []byte("b"), 0o644))
+	assert.Equal(t, fsnotify.Event{Name: created, Op: fsnotify.Create}, next())
+
+	require.NoError(t, os.Remove(file))
+	assert.Equal(t, fsnotify.Event{Name: file, Op: fsnotify.Remove}, next())
+
+	// Subdirectories are not watched
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "c.txt"), []byte("c"), 0o644))
+	select {
+	case event := <-w.Events():
+		assert.Fail(t, "unexpected event", event.String())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPollClose(t *testing.T) {
+	t.Parallel()
+
+	w := watcher.NewPoll(10 * time.Millisecond)
+	require.NoError(t, w.Add(t.TempDir()))
+	require.Error(t, w.Add(filepath.Join(t.TempDir(), "missing")))
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	_, ok := <-w.Events()
+	assert.False(t, ok)
+	_, ok = <-w.Errors()
+	assert.False(t, ok)
+}
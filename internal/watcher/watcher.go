@@ -0,0 +1,49 @@
+// Package watcher notifies about changes to the files of directories, either
+// through the events of the file system or by polling it.
+package watcher
+
+import (
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reports the changes to the files of the directories added to it.
+// Like fsnotify, it doesn't watch subdirectories recursively.
+type Watcher interface {
+	// Add starts watching the given directory.
+	Add(dir string) error
+	// Events returns the channel of changes. It is closed by Close.
+	Events() <-chan fsnotify.Event
+	// Errors returns the channel of errors. It is closed by Close.
+	Errors() <-chan error
+	// Close stops watching all directories.
+	Close() error
+}
+
+// FSNotify is a [Watcher] that relies on the events of the file system.
+type FSNotify struct {
+	w *fsnotify.Watcher
+}
+
+func NewFSNotify() (*FSNotify, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &FSNotify{w: w}, nil
+}
+
+func (w *FSNotify) Add(dir string) error {
+	return w.w.Add(dir)
+}
+
+func (w *FSNotify) Events() <-chan fsnotify.Event {
+	return w.w.Events
+}
+
+func (w *FSNotify) Errors() <-chan error {
+	return w.w.Errors
+}
+
+func (w *FSNotify) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,94 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/input"
+	"github.com/go-task/task/v3/internal/term"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// PickTask opens a searchable list of the tasks where the user selects the
+// task to run. It then prompts for the required variables of the task that
+// are not set, and returns the call to run it.
+func (e *Executor) PickTask() (*Call, error) {
+	if !e.AssumeTerm && !term.IsTerminal() {
+		return nil, errors.New("task: The task picker requires a terminal")
+	}
+
+	tasks, err := e.GetTaskList(FilterOutInternal)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("task: No tasks available")
+	}
+
+	items := make([]input.PickItem, len(tasks))
+	for i, t := range tasks {
+		items[i] = input.PickItem{
+			Name:     t.Task,
+			Aliases:  t.Aliases,
+			Desc:     t.Desc,
+			Summary:  t.Summary,
+			Requires: requiredVarNames(t),
+		}
+	}
+
+	var suggest func(string) []string
+	if !e.DisableFuzzy {
+		e.fuzzyModelOnce.Do(e.setupFuzzyModel)
+		if e.fuzzyModel != nil {
+			suggest = func(query string) []string {
+				return e.fuzzyModel.Suggestions(query, false)
+			}
+		}
+	}
+
+	prompter := e.newPrompter()
+	i, err := prompter.Pick(items, suggest)
+	if err != nil {
+		if errors.Is(err, input.ErrCancelled) {
+			return nil, &errors.TaskCancelledByUserError{TaskName: "task picker"}
+		}
+		return nil, err
+	}
+
+	call := &Call{Task: tasks[i].Task, Vars: ast.NewVars()}
+	t, err := e.FastCompiledTask(call)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range getMissingRequiredVars(t) {
+		value, err := promptRequiredVar(prompter, v, t.Dir)
+		if err != nil {
+			if errors.Is(err, input.ErrCancelled) {
+				return nil, &errors.TaskCancelledByUserError{TaskName: t.Name()}
+			}
+			return nil, err
+		}
+		call.Vars.Set(v.Name, ast.Var{Value: value})
+	}
+	return call, nil
+}
+
+// requiredVarNames returns the names of the variables required by the task,
+// along with their allowed values or their type.
+func requiredVarNames(t *ast.Task) []string {
+	if t.Requires == nil {
+		return nil
+	}
+	names := make([]string, len(t.Requires.Vars))
+	for i, v := range t.Requires.Vars {
+		names[i] = v.Name
+		switch {
+		case len(v.Enum) > 0:
+			names[i] = fmt.Sprintf("%s (%s)", v.Name, strings.Join(v.Enum, "|"))
+		case v.Type != "":
+			names[i] = fmt.Sprintf("%s (%s)", v.Name, v.Type)
+		}
+	}
+	return names
+}
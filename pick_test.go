@@ -0,0 +1,73 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestPickTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		task   string
+		output string
+	}{
+		{"first task", "\r", "build", "building"},
+		{"search", "depl\r", "deploy", "deploying to staging"},
+		{"move down", "\x1b[B\r", "deploy", "deploying to staging"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			e := task.NewExecutor(
+				task.WithDir("testdata/pick"),
+				task.WithStdin(strings.NewReader(test.input)),
+				task.WithStdout(&buff),
+				task.WithStderr(&buff),
+				task.WithAssumeTerm(true),
+			)
+			require.NoError(t, e.Setup())
+			// Set like a variable given on the command line, so that there's
+			// no prompt for it
+			e.Taskfile.Vars.Set("ENV", ast.Var{Value: "staging"})
+
+			call, err := e.PickTask()
+			require.NoError(t, err)
+			assert.Equal(t, test.task, call.Task)
+
+			buff.Reset()
+			require.NoError(t, e.Run(context.Background(), call))
+			assert.Contains(t, buff.String(), test.output)
+		})
+	}
+}
+
+func TestPickTaskCancelled(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir("testdata/pick"),
+		task.WithStdin(strings.NewReader("\x03")),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithAssumeTerm(true),
+	)
+	require.NoError(t, e.Setup())
+
+	_, err := e.PickTask()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cancelled by user")
+}
@@ -1,9 +1,11 @@
 package task
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/input"
 	"github.com/go-task/task/v3/internal/term"
 	"github.com/go-task/task/v3/taskfile/ast"
@@ -33,6 +35,7 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 	// Collect all missing vars from the dependency tree
 	visited := make(map[string]bool)
 	varsMap := make(map[string]*ast.VarsWithValidation)
+	dirs := make(map[string]string)
 
 	var collect func(call *Call) error
 	collect = func(call *Call) error {
@@ -44,6 +47,7 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 		for _, v := range getMissingRequiredVars(compiledTask) {
 			if _, exists := varsMap[v.Name]; !exists {
 				varsMap[v.Name] = v
+				dirs[v.Name] = compiledTask.Dir
 			}
 		}
 
@@ -81,7 +85,7 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 	e.promptedVars = ast.NewVars()
 
 	for _, v := range varsMap {
-		value, err := prompter.Prompt(v.Name, v.Enum)
+		value, err := promptRequiredVar(prompter, v, dirs[v.Name])
 		if err != nil {
 			if errors.Is(err, input.ErrCancelled) {
 				return &errors.TaskCancelledByUserError{TaskName: "interactive prompt"}
@@ -120,7 +124,7 @@ func (e *Executor) promptTaskVars(t *ast.Task, call *Call) (bool, error) {
 	prompter := e.newPrompter()
 
 	for _, v := range missing {
-		value, err := prompter.Prompt(v.Name, v.Enum)
+		value, err := promptRequiredVar(prompter, v, t.Dir)
 		if err != nil {
 			if errors.Is(err, input.ErrCancelled) {
 				return false, &errors.TaskCancelledByUserError{TaskName: t.Name()}
@@ -144,13 +148,49 @@ func (e *Executor) promptTaskVars(t *ast.Task, call *Call) (bool, error) {
 	return true, nil
 }
 
-// getMissingRequiredVars returns required vars that are not set in the task's vars.
+// promptRequiredVar prompts for the value of a required variable, which is
+// validated as it is typed. Paths are relative to the given directory.
+func promptRequiredVar(prompter *input.Prompter, v *ast.VarsWithValidation, dir string) (string, error) {
+	return prompter.Prompt(v.Name, v.Enum, v.Type, func(value string) error {
+		return v.Validate(value, dir)
+	})
+}
+
+// setRequiredVarDefaults sets the required vars that are not set in the task's
+// vars to their default values, if they have one. Returns true if any var was
+// set (caller should recompile the task).
+func setRequiredVarDefaults(t *ast.Task, call *Call) bool {
+	if t.Requires == nil {
+		return false
+	}
+	var set bool
+	for _, v := range t.Requires.Vars {
+		if v.Default == nil {
+			continue
+		}
+		if _, ok := t.Vars.Get(v.Name); ok {
+			continue
+		}
+		if call.Vars == nil {
+			call.Vars = ast.NewVars()
+		}
+		call.Vars.Set(v.Name, ast.Var{Value: *v.Default})
+		set = true
+	}
+	return set
+}
+
+// getMissingRequiredVars returns required vars that are not set in the task's
+// vars and have no default value.
 func getMissingRequiredVars(t *ast.Task) []*ast.VarsWithValidation {
 	if t.Requires == nil {
 		return nil
 	}
 	var missing []*ast.VarsWithValidation
 	for _, v := range t.Requires.Vars {
+		if v.Default != nil {
+			continue
+		}
 		if _, ok := t.Vars.Get(v.Name); !ok {
 			missing = append(missing, v)
 		}
@@ -190,9 +230,26 @@ func (e *Executor) areTaskRequiredVarsAllowedValuesSet(t *ast.Task) error {
 		value, isString := varValue.Value.(string)
 		if isString && requiredVar.Enum != nil && !slices.Contains(requiredVar.Enum, value) {
 			notAllowedValuesVars = append(notAllowedValuesVars, errors.NotAllowedVar{
-				Value: value,
-				Enum:  requiredVar.Enum,
-				Name:  requiredVar.Name,
+				Value:    value,
+				Enum:     requiredVar.Enum,
+				Name:     requiredVar.Name,
+				Location: requiredVarLocation(requiredVar),
+			})
+			continue
+		}
+
+		// Dynamic variables are not evaluated yet, so their value can't be
+		// checked
+		text, isScalar := scalarText(varValue.Value)
+		if !isScalar || varValue.Sh != nil {
+			continue
+		}
+		if err := requiredVar.Validate(text, t.Dir); err != nil {
+			notAllowedValuesVars = append(notAllowedValuesVars, errors.NotAllowedVar{
+				Value:    text,
+				Name:     requiredVar.Name,
+				Reason:   err.Error(),
+				Location: requiredVarLocation(requiredVar),
 			})
 		}
 	}
@@ -206,3 +263,21 @@ func (e *Executor) areTaskRequiredVarsAllowedValuesSet(t *ast.Task) error {
 
 	return nil
 }
+
+// scalarText returns the text of a string, boolean or number value.
+func scalarText(value any) (string, bool) {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return fmt.Sprint(value), true
+	}
+	return "", false
+}
+
+// requiredVarLocation returns where a required variable is declared, as
+// file:line:column.
+func requiredVarLocation(v *ast.VarsWithValidation) string {
+	if v.Location == nil || v.Location.Taskfile == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%d", filepathext.TryAbsToRel(v.Location.Taskfile), v.Location.Line, v.Location.Column)
+}
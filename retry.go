@@ -0,0 +1,60 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"mvdan.cc/sh/v3/interp"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// retryCommand calls run until it succeeds or the retry policy of the command
+// (or of its task, if the command has none and isn't deferred) is exhausted.
+// The error of the last attempt is returned.
+func (e *Executor) retryCommand(ctx context.Context, t *ast.Task, cmd *ast.Cmd, run func() error) error {
+	retry := cmd.Retry
+	if retry == nil && !cmd.Defer {
+		retry = t.Retry
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || retry == nil || attempt > retry.Count || !isRetryable(ctx, retry, err) {
+			return err
+		}
+
+		delay := retry.DelayFor(attempt)
+		e.Logger.Errf(logger.Yellow, "task: [%s] attempt %d/%d failed: %v. Retrying in %s\n", t.Name(), attempt, retry.Count+1, err, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryable returns true if the given error is one that the retry policy
+// allows to retry. Commands are never retried once the context they run in is
+// done, as that means the task itself was cancelled or timed out.
+func isRetryable(ctx context.Context, retry *ast.Retry, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var exitCode interp.ExitStatus
+	if errors.As(err, &exitCode) {
+		return retry.RetriesExitCode(int(exitCode))
+	}
+	// A command that timed out has no exit code, so only retry it when the
+	// policy isn't restricted to specific exit codes
+	var timeoutErr *errors.TaskTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return len(retry.ExitCodes) == 0
+	}
+	return false
+}
@@ -1,19 +1,23 @@
 package task
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"slices"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"mvdan.cc/sh/v3/interp"
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/internal/events"
 	"github.com/go-task/task/v3/internal/execext"
 	"github.com/go-task/task/v3/internal/fingerprint"
 	"github.com/go-task/task/v3/internal/logger"
@@ -29,6 +33,9 @@ const (
 	// MaximumTaskCall is the max number of times a task can be called.
 	// This exists to prevent infinite loops on cyclic dependencies
 	MaximumTaskCall = 1000
+	// TimeoutGracePeriod is the time a command is given to exit after being
+	// interrupted because of a timeout. After that, it is killed.
+	TimeoutGracePeriod = 5 * time.Second
 )
 
 // MatchingTask represents a task that matches a given call. It includes the
@@ -41,6 +48,7 @@ type MatchingTask struct {
 // Run runs Task
 func (e *Executor) Run(ctx context.Context, calls ...*Call) error {
 	// check if given tasks exist
+	tasks := make([]*ast.Task, 0, len(calls))
 	for _, call := range calls {
 		task, err := e.GetTask(call)
 		if err != nil {
@@ -60,6 +68,13 @@ func (e *Executor) Run(ctx context.Context, calls ...*Call) error {
 			}
 			return &errors.TaskInternalError{TaskName: call.Task}
 		}
+		tasks = append(tasks, task)
+	}
+
+	// Only the tasks that may run are checked, so that a cycle elsewhere in
+	// the Taskfile doesn't prevent running the other tasks
+	if err := e.checkTaskCycles(tasks...); err != nil {
+		return err
 	}
 
 	if e.Summary {
@@ -90,7 +105,7 @@ func (e *Executor) Run(ctx context.Context, calls ...*Call) error {
 	}
 	for _, c := range regularCalls {
 		if e.Parallel {
-			g.Go(func() error { return e.RunTask(ctx, c) })
+			g.Go(func() error { return e.RunTask(e.withNewLane(ctx), c) })
 		} else {
 			if err := e.RunTask(ctx, c); err != nil {
 				return err
@@ -145,6 +160,7 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 	}
 	if !shouldRunOnCurrentPlatform(t.Platforms) {
 		e.Logger.VerboseOutf(logger.Yellow, `task: %q not for current platform - ignored\n`, call.Task)
+		e.emit(ctx, events.Event{Type: events.TaskSkipped, Task: t.Name(), Reason: events.ReasonPlatform})
 		return nil
 	}
 
@@ -169,16 +185,19 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 			Env:     env.Get(t),
 		}); err != nil {
 			e.Logger.VerboseOutf(logger.Yellow, "task: if condition not met - skipped: %q\n", call.Task)
+			e.emit(ctx, events.Event{Type: events.TaskSkipped, Task: t.Name(), Reason: events.ReasonIf})
 			return nil
 		}
 	}
 
-	// Prompt for missing required vars after if check (avoid prompting if task won't run)
+	// Use the defaults of the required vars that are not set, and prompt for
+	// the other missing ones after if check (avoid prompting if task won't run)
+	defaulted := setRequiredVarDefaults(t, call)
 	prompted, err := e.promptTaskVars(t, call)
 	if err != nil {
 		return err
 	}
-	if prompted {
+	if defaulted || prompted {
 		// Recompile with the new vars
 		t, err = e.FastCompiledTask(call)
 		if err != nil {
@@ -201,15 +220,28 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 		}
 	}
 
-	release := e.acquireConcurrencyLimit()
+	release := e.acquireConcurrencyLimit(ctx, t)
 	defer release()
 
-	if err = e.startExecution(ctx, t, func(ctx context.Context) error {
+	if err = e.startExecution(ctx, t, func(ctx context.Context) (err error) {
 		e.Logger.VerboseErrf(logger.Magenta, "task: %q started\n", call.Task)
+		start := time.Now()
+		e.emit(ctx, events.Event{Type: events.TaskStarted, Task: t.Name()})
+		defer func() {
+			e.emit(ctx, events.Event{
+				Type:     events.TaskFinished,
+				Task:     t.Name(),
+				ExitCode: exitCodeOf(err),
+				Duration: time.Since(start),
+				Err:      err,
+			})
+		}()
+
 		if err := e.runDeps(ctx, t); err != nil {
 			return err
 		}
 
+		var cacheKey string
 		skipFingerprinting := e.ForceAll || (!call.Indirect && e.Force)
 		if !skipFingerprinting {
 			if err := ctx.Err(); err != nil {
@@ -226,8 +258,13 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 			if t.Method != "" {
 				method = t.Method
 			}
+			origTask, err := e.GetTask(call)
+			if err != nil {
+				return err
+			}
 			upToDate, err := fingerprint.IsTaskUpToDate(ctx, t,
 				fingerprint.WithMethod(method),
+				fingerprint.WithDefinition(origTask),
 				fingerprint.WithTempDir(e.TempDir.Fingerprint),
 				fingerprint.WithDry(e.Dry),
 				fingerprint.WithLogger(e.Logger),
@@ -244,8 +281,34 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 					}
 					e.Logger.Errf(logger.Magenta, "task: Task %q is up to date\n", name)
 				}
+				e.emit(ctx, events.Event{Type: events.TaskUpToDate, Task: t.Name()})
 				return nil
 			}
+
+			// The key hashes every source, so it is only computed once the
+			// task is known to be out of date
+			cacheKey = e.buildCacheKey(t, call)
+			if cacheKey != "" && preCondMet {
+				restored, err := e.restoreFromCache(t, cacheKey)
+				if err != nil {
+					e.Logger.VerboseErrf(logger.Yellow, "task: unable to restore from cache: %v\n", err)
+				}
+				// The up-to-date check already stored the fingerprint of the
+				// sources, so the restored outputs are up to date next time
+				if restored {
+					if e.Verbose || (!call.Silent && !t.IsSilent() && !e.Taskfile.Silent && !e.Silent) {
+						name := t.Name()
+						if e.OutputStyle.Name == "prefixed" {
+							name = t.Prefix
+						}
+						e.Logger.Errf(logger.Magenta, "task: Task %q restored from cache\n", name)
+					}
+					e.emit(ctx, events.Event{Type: events.TaskUpToDate, Task: t.Name(), Reason: events.ReasonCache})
+					return nil
+				}
+			}
+		} else {
+			cacheKey = e.buildCacheKey(t, call)
 		}
 
 		for _, p := range t.Prompt {
@@ -264,11 +327,22 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 			e.Logger.Errf(logger.Red, "task: cannot make directory %q: %v\n", t.Dir, err)
 		}
 
+		// The timeout only covers the commands of the task. Dependencies may
+		// be shared with other tasks, so they are bound by their own timeout.
+		if t.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeoutCause(ctx, t.Timeout, &errors.TaskTimeoutError{
+				TaskName: t.Name(),
+				Timeout:  t.Timeout,
+			})
+			defer cancel()
+		}
+
 		var deferredExitCode uint8
 
 		for i := range t.Cmds {
 			if t.Cmds[i].Defer {
-				defer e.runDeferred(t, call, i, t.Vars, &deferredExitCode)
+				defer e.runDeferred(ctx, t, call, i, t.Vars, &deferredExitCode)
 				continue
 			}
 
@@ -289,6 +363,11 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 				return err
 			}
 		}
+		if cacheKey != "" {
+			if err := e.saveToCache(t, cacheKey); err != nil {
+				e.Logger.VerboseErrf(logger.Yellow, "task: unable to save to cache: %v\n", err)
+			}
+		}
 		e.Logger.VerboseErrf(logger.Magenta, "task: %q finished\n", call.Task)
 		return nil
 	}); err != nil {
@@ -321,12 +400,12 @@ func (e *Executor) runDeps(ctx context.Context, t *ast.Task) error {
 		g, ctx = errgroup.WithContext(ctx)
 	}
 
-	reacquire := e.releaseConcurrencyLimit()
+	reacquire := e.releaseConcurrencyLimit(ctx, t)
 	defer reacquire()
 
 	for _, d := range t.Deps {
 		g.Go(func() error {
-			err := e.RunTask(ctx, &Call{Task: d.Task, Vars: d.Vars, Silent: d.Silent, Indirect: true})
+			err := e.RunTask(e.withNewLane(ctx), &Call{Task: d.Task, Vars: d.Vars, Silent: d.Silent, Indirect: true})
 			if err != nil {
 				return err
 			}
@@ -337,8 +416,9 @@ func (e *Executor) runDeps(ctx context.Context, t *ast.Task) error {
 	return g.Wait()
 }
 
-func (e *Executor) runDeferred(t *ast.Task, call *Call, i int, vars *ast.Vars, deferredExitCode *uint8) {
-	ctx, cancel := context.WithCancel(context.Background())
+func (e *Executor) runDeferred(ctx context.Context, t *ast.Task, call *Call, i int, vars *ast.Vars, deferredExitCode *uint8) {
+	// Deferred commands must run even if the task was cancelled
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
 	defer cancel()
 
 	cmd := t.Cmds[i]
@@ -354,7 +434,17 @@ func (e *Executor) runDeferred(t *ast.Task, call *Call, i int, vars *ast.Vars, d
 	cmd.If = templater.ReplaceWithExtra(cmd.If, cache, extra)
 	cmd.Vars = templater.ReplaceVarsWithExtra(cmd.Vars, cache, extra)
 
-	if err := e.runCommand(ctx, t, call, i); err != nil {
+	start := time.Now()
+	err := e.runCommand(ctx, t, call, i)
+	e.emit(ctx, events.Event{
+		Type:     events.DeferredRan,
+		Task:     t.Name(),
+		Command:  cmp.Or(cmd.Cmd, cmd.Task),
+		ExitCode: exitCodeOf(err),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+	if err != nil {
 		e.Logger.VerboseErrf(logger.Yellow, "task: ignored error in deferred cmd: %s\n", err.Error())
 	}
 }
@@ -376,9 +466,12 @@ func (e *Executor) runCommand(ctx context.Context, t *ast.Task, call *Call, i in
 
 	switch {
 	case cmd.Task != "":
-		reacquire := e.releaseConcurrencyLimit()
+		reacquire := e.releaseConcurrencyLimit(ctx, t)
 		defer reacquire()
 
+		ctx, cancel := withCmdTimeout(ctx, t, cmd)
+		defer cancel()
+
 		err := e.RunTask(ctx, &Call{Task: cmd.Task, Vars: cmd.Vars, Silent: cmd.Silent, Indirect: true})
 		var exitCode interp.ExitStatus
 		if errors.As(err, &exitCode) && cmd.IgnoreError {
@@ -400,30 +493,9 @@ func (e *Executor) runCommand(ctx context.Context, t *ast.Task, call *Call, i in
 			return nil
 		}
 
-		outputWrapper := e.Output
-		if t.Interactive {
-			outputWrapper = output.Interleaved{}
-		}
-		vars, err := e.Compiler.FastGetVariables(t, call)
-		outputTemplater := &templater.Cache{Vars: vars}
-		if err != nil {
-			return fmt.Errorf("task: failed to get variables: %w", err)
-		}
-		stdOut, stdErr, closer := outputWrapper.WrapWriter(e.Stdout, e.Stderr, t.Prefix, outputTemplater)
-
-		err = execext.RunCommand(ctx, &execext.RunCommandOptions{
-			Command:   cmd.Cmd,
-			Dir:       t.Dir,
-			Env:       env.Get(t),
-			PosixOpts: slicesext.UniqueJoin(e.Taskfile.Set, t.Set, cmd.Set),
-			BashOpts:  slicesext.UniqueJoin(e.Taskfile.Shopt, t.Shopt, cmd.Shopt),
-			Stdin:     e.Stdin,
-			Stdout:    stdOut,
-			Stderr:    stdErr,
+		err := e.retryCommand(ctx, t, cmd, func() error {
+			return e.runShellCommand(ctx, t, call, cmd)
 		})
-		if closeErr := closer(err); closeErr != nil {
-			e.Logger.Errf(logger.Red, "task: unable to close writer: %v\n", closeErr)
-		}
 		var exitCode interp.ExitStatus
 		if errors.As(err, &exitCode) && cmd.IgnoreError {
 			e.Logger.VerboseErrf(logger.Yellow, "task: [%s] command error ignored: %v\n", t.Name(), err)
@@ -435,6 +507,99 @@ func (e *Executor) runCommand(ctx context.Context, t *ast.Task, call *Call, i in
 	}
 }
 
+func (e *Executor) runShellCommand(ctx context.Context, t *ast.Task, call *Call, cmd *ast.Cmd) error {
+	ctx, cancel := withCmdTimeout(ctx, t, cmd)
+	defer cancel()
+
+	outputWrapper := e.Output
+	if t.Interactive {
+		outputWrapper = output.Interleaved{}
+	}
+	vars, err := e.Compiler.FastGetVariables(t, call)
+	outputTemplater := &templater.Cache{Vars: vars}
+	if err != nil {
+		return fmt.Errorf("task: failed to get variables: %w", err)
+	}
+	stdOut, stdErr, closer := outputWrapper.WrapWriter(e.Stdout, e.Stderr, t.Prefix, outputTemplater)
+
+	var captured *tailBuffer
+	if !cmd.Defer && !t.Interactive && e.capturesOutput() {
+		captured = newTailBuffer(maxCapturedOutput)
+		stdOut = io.MultiWriter(stdOut, captured)
+		stdErr = io.MultiWriter(stdErr, captured)
+	}
+
+	// Deadlines are only set by timeouts, which get a longer grace period
+	var killTimeout time.Duration
+	if _, ok := ctx.Deadline(); ok {
+		killTimeout = TimeoutGracePeriod
+	}
+
+	start := time.Now()
+	if !cmd.Defer {
+		e.emit(ctx, events.Event{Type: events.CommandStarted, Task: t.Name(), Command: cmd.Cmd})
+	}
+	err = execext.RunCommand(ctx, &execext.RunCommandOptions{
+		Command:     cmd.Cmd,
+		Dir:         t.Dir,
+		Env:         env.Get(t),
+		PosixOpts:   slicesext.UniqueJoin(e.Taskfile.Set, t.Set, cmd.Set),
+		BashOpts:    slicesext.UniqueJoin(e.Taskfile.Shopt, t.Shopt, cmd.Shopt),
+		Stdin:       e.Stdin,
+		Stdout:      stdOut,
+		Stderr:      stdErr,
+		KillTimeout: killTimeout,
+	})
+	if err != nil {
+		if timeoutErr := timeoutError(ctx); timeoutErr != nil {
+			err = timeoutErr
+		}
+	}
+	if !cmd.Defer {
+		var output string
+		if captured != nil && err != nil {
+			output = captured.String()
+		}
+		e.emit(ctx, events.Event{
+			Type:     events.CommandFinished,
+			Task:     t.Name(),
+			Command:  cmd.Cmd,
+			ExitCode: exitCodeOf(err),
+			Duration: time.Since(start),
+			Err:      err,
+			Output:   output,
+		})
+	}
+	if closeErr := closer(err); closeErr != nil {
+		e.Logger.Errf(logger.Red, "task: unable to close writer: %v\n", closeErr)
+	}
+	return err
+}
+
+// withCmdTimeout returns a copy of ctx that is cancelled with an
+// [errors.TaskTimeoutError] once the timeout of the given command elapses.
+func withCmdTimeout(ctx context.Context, t *ast.Task, cmd *ast.Cmd) (context.Context, context.CancelFunc) {
+	if cmd.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, cmd.Timeout, &errors.TaskTimeoutError{
+		TaskName: t.Name(),
+		Command:  cmp.Or(cmd.Cmd, cmd.Task),
+		Timeout:  cmd.Timeout,
+	})
+}
+
+// timeoutError returns the [errors.TaskTimeoutError] that caused the given
+// context to be done, or nil if it is still running or was cancelled for
+// another reason.
+func timeoutError(ctx context.Context) error {
+	var timeoutErr *errors.TaskTimeoutError
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		return timeoutErr
+	}
+	return nil
+}
+
 func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func(ctx context.Context) error) error {
 	h, err := e.GetHash(t)
 	if err != nil {
@@ -450,9 +615,10 @@ func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func
 	if otherExecutionCtx, ok := e.executionHashes[h]; ok {
 		e.executionHashesMutex.Unlock()
 		e.Logger.VerboseErrf(logger.Magenta, "task: skipping execution of task: %s\n", h)
+		e.emit(ctx, events.Event{Type: events.TaskSkipped, Task: t.Name(), Reason: events.ReasonDuplicate})
 
 		// Release our execution slot to avoid blocking other tasks while we wait
-		reacquire := e.releaseConcurrencyLimit()
+		reacquire := e.releaseConcurrencyLimit(ctx, t)
 		defer reacquire()
 
 		<-otherExecutionCtx.Done()
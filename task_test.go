@@ -440,6 +440,7 @@ func TestStatusChecksum(t *testing.T) { // nolint:paralleltest // cannot run in
 		{[]string{"generated.txt", ".task/checksum/build"}, "build"},
 		{[]string{"generated-wildcard.txt", ".task/checksum/build-wildcard"}, "build-wildcard"},
 		{[]string{"generated.txt", ".task/checksum/build-with-status"}, "build-with-status"},
+		{[]string{"generated.txt", ".task/checksum/build-with-dynamic-var"}, "build-with-dynamic-var"},
 	}
 
 	for _, test := range tests { // nolint:paralleltest // cannot run in parallel
@@ -570,6 +571,31 @@ func TestCyclicDep(t *testing.T) {
 		task.WithStderr(io.Discard),
 	)
 	require.NoError(t, e.Setup())
+
+	// Tasks outside of the cycle can still run
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "task-3"}))
+
+	err := e.Run(t.Context(), &task.Call{Task: "task-1"})
+	var taskCycleError *errors.TaskCycleError
+	require.ErrorAs(t, err, &taskCycleError)
+	assert.Equal(t, []string{"task-1", "task-2", "task-1"}, taskCycleError.Cycle)
+	require.Len(t, taskCycleError.Steps, 2)
+	assert.Equal(t, "testdata/cyclic/Taskfile.yml", filepath.ToSlash(taskCycleError.Steps[0].Location))
+	assert.Equal(t, 6, taskCycleError.Steps[0].Line)
+	assert.Equal(t, 10, taskCycleError.Steps[1].Line)
+}
+
+func TestCyclicDepWithVars(t *testing.T) {
+	t.Parallel()
+
+	// Recursive calls that pass variables may end, so they are only caught
+	// when running the task
+	e := task.NewExecutor(
+		task.WithDir("testdata/cyclic_vars"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
 	err := e.Run(t.Context(), &task.Call{Task: "task-1"})
 	var taskCalledTooManyTimesError *errors.TaskCalledTooManyTimesError
 	assert.ErrorAs(t, err, &taskCalledTooManyTimesError)
@@ -1959,6 +1985,127 @@ func TestExitCodeOne(t *testing.T) {
 	assert.Equal(t, "FOO=bar - DYNAMIC_FOO=bar - EXIT_CODE=1", strings.TrimSpace(buff.String()))
 }
 
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		task    string
+		wantErr string
+	}{
+		{task: "task-timeout", wantErr: `task: Task "task-timeout" timed out after 100ms`},
+		{task: "cmd-timeout", wantErr: `task: Command "sleep 5" in task "cmd-timeout" timed out after 100ms`},
+		{task: "task-call-timeout", wantErr: `task: Task "task-timeout" timed out after 100ms`},
+		{task: "deferred", wantErr: `task: Task "deferred" timed out after 100ms`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.task, func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			e := task.NewExecutor(
+				task.WithDir("testdata/timeout"),
+				task.WithStdout(&buff),
+				task.WithStderr(&buff),
+			)
+			require.NoError(t, e.Setup())
+
+			start := time.Now()
+			err := e.Run(t.Context(), &task.Call{Task: test.task})
+			require.Less(t, time.Since(start), 5*time.Second)
+
+			var timeoutErr *errors.TaskTimeoutError
+			require.ErrorAs(t, err, &timeoutErr)
+			assert.Equal(t, test.wantErr, timeoutErr.Error())
+
+			var runErr *errors.TaskRunError
+			require.ErrorAs(t, err, &runErr)
+			assert.Equal(t, errors.CodeTaskTimeout, runErr.Code())
+			assert.Equal(t, errors.CodeTaskTimeout, runErr.TaskExitCode())
+		})
+	}
+
+	t.Run("deferred cmds still run", func(t *testing.T) {
+		t.Parallel()
+
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir("testdata/timeout"),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.Error(t, e.Run(t.Context(), &task.Call{Task: "deferred"}))
+		assert.Equal(t, "cleanup", strings.TrimSpace(buff.String()))
+	})
+
+	t.Run("not timed out", func(t *testing.T) {
+		t.Parallel()
+
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir("testdata/timeout"),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "not-timed-out"}))
+		assert.Equal(t, "done", strings.TrimSpace(buff.String()))
+	})
+
+	t.Run("deps are not covered", func(t *testing.T) {
+		t.Parallel()
+
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir("testdata/timeout"),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "slow-deps"}))
+		assert.Equal(t, "done", strings.TrimSpace(buff.String()))
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		task     string
+		wantErr  bool
+		attempts int
+	}{
+		{task: "succeeds-eventually", attempts: 2},
+		{task: "exhausted", wantErr: true, attempts: 2},
+		{task: "exit-code-not-retried", wantErr: true, attempts: 0},
+		{task: "timeout-retried", wantErr: true, attempts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.task, func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			e := task.NewExecutor(
+				task.WithDir("testdata/retry"),
+				task.WithStdout(&buff),
+				task.WithStderr(&buff),
+			)
+			require.NoError(t, e.Setup())
+
+			err := e.Run(t.Context(), &task.Call{Task: test.task})
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, buff.String(), "done")
+			}
+			assert.Equal(t, test.attempts, strings.Count(buff.String(), "Retrying in"), buff.String())
+		})
+	}
+}
+
 func TestIgnoreNilElements(t *testing.T) {
 	t.Parallel()
 
@@ -2672,3 +2819,351 @@ func enableExperimentForTest(t *testing.T, e *experiments.Experiment, val int) {
 	}
 	t.Cleanup(func() { *e = prev })
 }
+
+func TestBuildCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := os.ReadFile("testdata/build_cache/Taskfile.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+
+	run := func(src string, vars ...string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte(src), 0o644))
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		call := &task.Call{Task: "build", Vars: ast.NewVars()}
+		for i := 0; i < len(vars); i += 2 {
+			call.Vars.Set(vars[i], ast.Var{Value: vars[i+1]})
+		}
+		require.NoError(t, e.Run(t.Context(), call))
+		return buff.String()
+	}
+	result := func() string {
+		b, err := os.ReadFile(filepath.Join(dir, "out", "result.txt"))
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	assert.Contains(t, run("one"), "built app")
+	assert.Contains(t, run("two"), "built app")
+	assert.Equal(t, "two", result())
+
+	// Going back to previous sources restores their outputs
+	assert.Equal(t, "task: Task \"build\" restored from cache\n", run("one"))
+	assert.Equal(t, "one", result())
+	assert.Equal(t, "task: Task \"build\" is up to date\n", run("one"))
+
+	// Outputs are restored even after they were deleted
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "out")))
+	assert.Equal(t, "task: Task \"build\" restored from cache\n", run("one"))
+	assert.Equal(t, "one", result())
+	assert.Equal(t, "task: Task \"build\" is up to date\n", run("one"))
+
+	// Variables are part of the key
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "out")))
+	assert.Contains(t, run("one", "NAME", "other"), "built other")
+}
+
+func TestBuildCacheRemote(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	blobs := map[string][]byte{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			b, ok := blobs[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(b)
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			blobs[r.URL.Path] = b
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	b, err := os.ReadFile("testdata/build_cache/Taskfile.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("remote"), 0o644))
+
+	// Each local state is like a different machine
+	run := func(state string) string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithTempDir(task.TempDir{Fingerprint: state}),
+			task.WithBuildCacheURL(srv.URL),
+			task.WithInsecure(true),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+		return buff.String()
+	}
+
+	assert.Contains(t, run(t.TempDir()), "built app")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "out")))
+	state := t.TempDir()
+	assert.Equal(t, "task: Task \"build\" restored from cache\n", run(state))
+	b, err = os.ReadFile(filepath.Join(dir, "out", "result.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "remote", string(b))
+
+	// The fingerprint of the sources is stored along with the restored outputs
+	assert.Equal(t, "task: Task \"build\" is up to date\n", run(state))
+}
+
+func TestFingerprintExec(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := os.ReadFile("testdata/fingerprint_exec/Taskfile.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "version.txt"), []byte("1"), 0o644))
+
+	run := func(taskName string) (string, error) {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithSilent(true),
+		)
+		require.NoError(t, e.Setup())
+		err := e.Run(t.Context(), &task.Call{Task: taskName})
+		return buff.String(), err
+	}
+
+	out, err := run("build")
+	require.NoError(t, err)
+	assert.Equal(t, "built app\n", out)
+
+	// The fingerprint didn't change
+	out, err = run("build")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "version.txt"), []byte("2"), 0o644))
+	out, err = run("build")
+	require.NoError(t, err)
+	assert.Equal(t, "built app\n", out)
+
+	_, err = run("failing")
+	require.ErrorContains(t, err, `fingerprint command of task "failing" failed`)
+}
+
+func TestWhy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := os.ReadFile("testdata/why/Taskfile.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+
+	why := func(calls ...*task.Call) string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithDry(true),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Why(t.Context(), calls...))
+		return buff.String()
+	}
+
+	assert.Equal(t, strings.Join([]string{
+		`task: Task "build" is not up to date`,
+		`  sources (method checksum):`,
+		`    no fingerprint of a previous run`,
+		`    missing generates: out`,
+		`  status:`,
+		`    failed: echo "checking out" && test -f out (exit status 1)`,
+		`      checking out`,
+		`task: Task "always" is not up to date`,
+		`  it has no sources or status, so it always runs`,
+		``,
+	}, "\n"), why(&task.Call{Task: "build"}, &task.Call{Task: "always"}))
+
+	e := task.NewExecutor(task.WithDir(dir), task.WithStdout(io.Discard), task.WithStderr(io.Discard))
+	require.NoError(t, e.Setup())
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+
+	assert.Equal(t, strings.Join([]string{
+		`task: Task "build" is up to date`,
+		`  sources (method checksum):`,
+		`    unchanged`,
+		`  status:`,
+		`    passed: echo "checking out" && test -f out`,
+		``,
+	}, "\n"), why(&task.Call{Task: "build"}))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o644))
+	assert.Contains(t, why(&task.Call{Task: "build"}), "    modified: a.txt\n    added: b.txt\n")
+
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+	b = bytes.Replace(b, []byte("touch out"), []byte("touch out && echo built"), 1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+	assert.Equal(t, strings.Join([]string{
+		`task: Task "build" is up to date`,
+		`  sources (method checksum):`,
+		`    the definition of the task changed since the last run`,
+		`    unchanged`,
+		`  status:`,
+		`    passed: echo "checking out" && test -f out`,
+		``,
+	}, "\n"), why(&task.Call{Task: "build"}))
+}
+
+func TestTrack(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := os.ReadFile("testdata/track/Taskfile.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("src"), 0o644))
+
+	run := func(call *task.Call, env ...string) string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithSilent(true),
+		)
+		require.NoError(t, e.Setup())
+		if len(env) > 0 {
+			e.Taskfile.Env.Set(env[0], ast.Var{Value: env[1]})
+		}
+		require.NoError(t, e.Run(t.Context(), call))
+		return buff.String()
+	}
+	withVars := func(name string, vars map[string]string) *task.Call {
+		call := &task.Call{Task: name, Vars: ast.NewVars()}
+		for k, v := range vars {
+			call.Vars.Set(k, ast.Var{Value: v})
+		}
+		return call
+	}
+
+	t.Run("vars and env", func(t *testing.T) { //nolint:paralleltest // runs in order
+		assert.Equal(t, "built for linux\n", run(withVars("build", nil)))
+		assert.Empty(t, run(withVars("build", nil)))
+		assert.Equal(t, "built for windows\n", run(withVars("build", map[string]string{"GOOS": "windows"})))
+		assert.Empty(t, run(withVars("build", map[string]string{"GOOS": "windows"})))
+		assert.Equal(t, "built for windows\n", run(withVars("build", map[string]string{"GOOS": "windows"}), "TASK_TEST_TRACK_ENV", "1"))
+		assert.Empty(t, run(withVars("build", map[string]string{"GOOS": "windows"}), "TASK_TEST_TRACK_ENV", "1"))
+	})
+
+	t.Run("all", func(t *testing.T) { //nolint:paralleltest // runs in order
+		assert.Equal(t, "built in debug\n", run(withVars("build-all", nil)))
+		assert.Empty(t, run(withVars("build-all", nil)))
+		assert.Equal(t, "built in release\n", run(withVars("build-all", map[string]string{"MODE": "release"})))
+		assert.Empty(t, run(withVars("build-all", map[string]string{"MODE": "release"})))
+	})
+}
+
+func TestCleanFingerprints(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"Taskfile.yml", "docs/Taskfile.yml"} {
+		b, err := os.ReadFile(filepath.Join("testdata/clean_fingerprints", name))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), b, 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("src"), 0o644))
+
+	newExecutor := func(buff *bytes.Buffer, opts ...task.ExecutorOption) *task.Executor {
+		e := task.NewExecutor(append([]task.ExecutorOption{
+			task.WithDir(dir),
+			task.WithStdout(buff),
+			task.WithStderr(buff),
+		}, opts...)...)
+		require.NoError(t, e.Setup())
+		return e
+	}
+
+	var buff bytes.Buffer
+	e := newExecutor(&buff)
+	lint := &task.Call{Task: "lint", Vars: ast.NewVars()}
+	lint.Vars.Set("TARGET", ast.Var{Value: "app"})
+	require.NoError(t, e.Run(t.Context(),
+		&task.Call{Task: "build"},
+		&task.Call{Task: "gen-foo"},
+		&task.Call{Task: "docs:site"},
+		lint,
+	))
+	orphan := filepath.Join(dir, ".task", "checksum", "old-task")
+	require.NoError(t, os.WriteFile(orphan, []byte("1234\n"), 0o644))
+	stale := filepath.Join(dir, ".task", "cache", "cas", "stale")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o755))
+	require.NoError(t, os.WriteFile(stale, []byte("old"), 0o644))
+	staleTime := time.Now().Add(-60 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(stale, staleTime, staleTime))
+
+	clean := func(dry bool, names ...string) string {
+		var buff bytes.Buffer
+		require.NoError(t, newExecutor(&buff, task.WithDry(dry)).CleanFingerprints(names...))
+		return buff.String()
+	}
+	exists := func(method, name string) bool {
+		_, err := os.Stat(filepath.Join(dir, ".task", method, name))
+		return err == nil
+	}
+
+	out := clean(true)
+	assert.Regexp(t, `checksum/build +build +\d+ B\n`, out)
+	assert.Regexp(t, `checksum/docs-site +docs:site +\d+ B\n`, out)
+	assert.Regexp(t, `checksum/lint-app +lint +\d+ B\n`, out)
+	assert.Regexp(t, `checksum/old-task +\(orphan\) +5 B +would be removed\n`, out)
+	assert.Regexp(t, `definition/build +build +\d+ B\n`, out)
+	assert.Regexp(t, `timestamp/gen-- +gen-\* +0 B\n`, out)
+	assert.Contains(t, out, "task: Would remove 1 of 9 fingerprints (5 B)")
+	assert.Contains(t, out, "task: Would remove 1 build cache file(s) unused for 30 days (3 B)")
+	assert.FileExists(t, orphan)
+	assert.FileExists(t, stale)
+
+	out = clean(false)
+	assert.Contains(t, out, "task: Removed 1 of 9 fingerprints (5 B)")
+	assert.Contains(t, out, "task: Removed 1 build cache file(s) unused for 30 days (3 B)")
+	assert.NoFileExists(t, orphan)
+	assert.NoFileExists(t, stale)
+
+	out = clean(false, "docs")
+	assert.Contains(t, out, "task: Removed 2 of 8 fingerprints")
+	assert.NotContains(t, out, "build cache")
+	assert.False(t, exists("checksum", "docs-site"))
+	assert.False(t, exists("definition", "docs-site"))
+	assert.True(t, exists("checksum", "build"))
+
+	assert.Contains(t, clean(false, "build", "gen-*"), "task: Removed 4 of 6 fingerprints")
+	assert.False(t, exists("checksum", "build"))
+	assert.False(t, exists("timestamp", "gen--"))
+	assert.True(t, exists("checksum", "lint-app"))
+
+	var buf bytes.Buffer
+	err := newExecutor(&buf).CleanFingerprints("missing")
+	var taskNotFoundErr *errors.TaskNotFoundError
+	require.ErrorAs(t, err, &taskNotFoundErr)
+}
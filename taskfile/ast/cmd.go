@@ -1,10 +1,13 @@
 package ast
 
 import (
+	"time"
+
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Cmd is a task command
@@ -20,6 +23,10 @@ type Cmd struct {
 	IgnoreError bool
 	Defer       bool
 	Platforms   []*Platform
+	Timeout     time.Duration
+	Retry       *Retry
+	// Location is where the command is declared in its Taskfile
+	Location *Location `hash:"ignore"`
 }
 
 func (c *Cmd) DeepCopy() *Cmd {
@@ -38,10 +45,32 @@ func (c *Cmd) DeepCopy() *Cmd {
 		IgnoreError: c.IgnoreError,
 		Defer:       c.Defer,
 		Platforms:   deepcopy.Slice(c.Platforms),
+		Timeout:     c.Timeout,
+		Retry:       c.Retry.DeepCopy(),
+		Location:    c.Location.DeepCopy(),
 	}
 }
 
+// cmdMapping is the mapping form of a command, which either runs a shell
+// command, calls a task or defers one of them
+type cmdMapping struct {
+	Cmd         string        `desc:"Command to run."`
+	Task        string        `desc:"Name of the task to run."`
+	For         *For          `desc:"Runs the command once for each of the values."`
+	If          string        `desc:"A shell command to evaluate. If the exit code is non-zero, the command is skipped."`
+	Silent      bool          `desc:"Hides the command from the output. Its output is still redirected to STDOUT and STDERR."`
+	Set         []string      `desc:"Enables POSIX shell options for this command. See https://www.gnu.org/software/bash/manual/html_node/The-Set-Builtin.html" enum:"allexport,a,errexit,e,noexec,n,noglob,f,nounset,u,xtrace,x,pipefail"`
+	Shopt       []string      `desc:"Enables Bash shell options for this command. See https://www.gnu.org/software/bash/manual/html_node/The-Shopt-Builtin.html" enum:"expand_aliases,globstar,nullglob"`
+	Vars        *Vars         `desc:"Values passed to the task called."`
+	IgnoreError bool          `yaml:"ignore_error" desc:"Continues running the task even if the command fails."`
+	Defer       *Defer        `desc:"Runs a command or calls a task when the task completes, even if it fails."`
+	Platforms   []*Platform   `desc:"Specifies which platforms the command should be run on."`
+	Timeout     time.Duration `desc:"Maximum duration the command may run for (e.g. 30s, 5m). When exceeded, the command is interrupted and then killed after a grace period."`
+	Retry       *Retry        `desc:"Retry policy for the command."`
+}
+
 func (c *Cmd) UnmarshalYAML(node *yaml.Node) error {
+	c.Location = &Location{Line: node.Line, Column: node.Column}
 	switch node.Kind {
 
 	case yaml.ScalarNode:
@@ -53,19 +82,7 @@ func (c *Cmd) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var cmdStruct struct {
-			Cmd         string
-			Task        string
-			For         *For
-			If          string
-			Silent      bool
-			Set         []string
-			Shopt       []string
-			Vars        *Vars
-			IgnoreError bool `yaml:"ignore_error"`
-			Defer       *Defer
-			Platforms   []*Platform
-		}
+		var cmdStruct cmdMapping
 		if err := node.Decode(&cmdStruct); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -92,12 +109,17 @@ func (c *Cmd) UnmarshalYAML(node *yaml.Node) error {
 
 		// A task call
 		if cmdStruct.Task != "" {
+			// Retrying would run the called task and its deps again
+			if cmdStruct.Retry != nil {
+				return errors.NewTaskfileDecodeError(nil, node).WithMessage("retry cannot be used when calling a task, set it on the called task instead")
+			}
 			c.Task = cmdStruct.Task
 			c.Vars = cmdStruct.Vars
 			c.For = cmdStruct.For
 			c.If = cmdStruct.If
 			c.Silent = cmdStruct.Silent
 			c.IgnoreError = cmdStruct.IgnoreError
+			c.Timeout = cmdStruct.Timeout
 			return nil
 		}
 
@@ -111,6 +133,8 @@ func (c *Cmd) UnmarshalYAML(node *yaml.Node) error {
 			c.Shopt = cmdStruct.Shopt
 			c.IgnoreError = cmdStruct.IgnoreError
 			c.Platforms = cmdStruct.Platforms
+			c.Timeout = cmdStruct.Timeout
+			c.Retry = cmdStruct.Retry
 			return nil
 		}
 
@@ -119,3 +143,15 @@ func (c *Cmd) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("command")
 }
+
+func (c *Cmd) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[cmdMapping](r)
+	mapping.AnyOf = []*jsonschema.Schema{
+		{Required: []string{"cmd"}},
+		{Required: []string{"task"}, Not: &jsonschema.Schema{Required: []string{"retry"}}},
+		{Required: []string{"defer"}},
+	}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{jsonschema.Type("string"), mapping},
+	}
+}
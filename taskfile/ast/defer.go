@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type Defer struct {
@@ -13,6 +14,14 @@ type Defer struct {
 	Silent bool
 }
 
+// deferMapping is the mapping form of a deferred command or task call
+type deferMapping struct {
+	Defer  string `desc:"Command to run."`
+	Task   string `desc:"Name of the task to run."`
+	Vars   *Vars  `desc:"Values passed to the task called."`
+	Silent bool   `desc:"Hides the task name and commands from the output."`
+}
+
 func (d *Defer) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -25,12 +34,7 @@ func (d *Defer) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var deferStruct struct {
-			Defer  string
-			Task   string
-			Vars   *Vars
-			Silent bool
-		}
+		var deferStruct deferMapping
 		if err := node.Decode(&deferStruct); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -43,3 +47,14 @@ func (d *Defer) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("defer")
 }
+
+func (d *Defer) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[deferMapping](r)
+	mapping.OneOf = []*jsonschema.Schema{
+		{Required: []string{"defer"}},
+		{Required: []string{"task"}},
+	}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{jsonschema.Type("string"), mapping},
+	}
+}
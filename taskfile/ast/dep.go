@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Dep is a task dependency
@@ -12,6 +13,8 @@ type Dep struct {
 	For    *For
 	Vars   *Vars
 	Silent bool
+	// Location is where the dependency is declared in its Taskfile
+	Location *Location `hash:"ignore"`
 }
 
 func (d *Dep) DeepCopy() *Dep {
@@ -19,14 +22,24 @@ func (d *Dep) DeepCopy() *Dep {
 		return nil
 	}
 	return &Dep{
-		Task:   d.Task,
-		For:    d.For.DeepCopy(),
-		Vars:   d.Vars.DeepCopy(),
-		Silent: d.Silent,
+		Task:     d.Task,
+		For:      d.For.DeepCopy(),
+		Vars:     d.Vars.DeepCopy(),
+		Silent:   d.Silent,
+		Location: d.Location.DeepCopy(),
 	}
 }
 
+// depMapping is the mapping form of a dependency
+type depMapping struct {
+	Task   string `desc:"Name of the task to run."`
+	For    *For   `desc:"Runs the task once for each of the values."`
+	Vars   *Vars  `desc:"Values passed to the task called."`
+	Silent bool   `desc:"Hides the task name and commands from the output."`
+}
+
 func (d *Dep) UnmarshalYAML(node *yaml.Node) error {
+	d.Location = &Location{Line: node.Line, Column: node.Column}
 	switch node.Kind {
 
 	case yaml.ScalarNode:
@@ -38,12 +51,7 @@ func (d *Dep) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var taskCall struct {
-			Task   string
-			For    *For
-			Vars   *Vars
-			Silent bool
-		}
+		var taskCall depMapping
 		if err := node.Decode(&taskCall); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -56,3 +64,12 @@ func (d *Dep) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("dependency")
 }
+
+func (d *Dep) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[depMapping](r),
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type For struct {
@@ -16,6 +17,15 @@ type For struct {
 	As     string
 }
 
+// forMapping is the mapping form of a loop, over the values of a variable or
+// the combinations of a matrix
+type forMapping struct {
+	Matrix *Matrix `desc:"A matrix of values to iterate over."`
+	Var    string  `desc:"Name of the variable to iterate over. It is split on whitespaces, unless split is set."`
+	Split  string  `desc:"String to split the variable on."`
+	As     string  `desc:"Name of the loop variable. Defaults to ITEM."`
+}
+
 func (f *For) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -36,12 +46,7 @@ func (f *For) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var forStruct struct {
-			Matrix *Matrix
-			Var    string
-			Split  string
-			As     string
-		}
+		var forStruct forMapping
 		if err := node.Decode(&forStruct); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -61,6 +66,22 @@ func (f *For) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("for")
 }
 
+func (f *For) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	attribute := jsonschema.Type("string")
+	attribute.Description = "The task attribute to iterate over."
+	attribute.Enum = []any{"sources", "generates"}
+	list := jsonschema.Reflect[[]any](r)
+	list.Description = "A list of values to iterate over."
+	mapping := jsonschema.Reflect[forMapping](r)
+	mapping.OneOf = []*jsonschema.Schema{
+		{Required: []string{"var"}},
+		{Required: []string{"matrix"}},
+	}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{attribute, list, mapping},
+	}
+}
+
 func (f *For) DeepCopy() *For {
 	if f == nil {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type Glob struct {
@@ -11,6 +12,12 @@ type Glob struct {
 	Negate bool
 }
 
+// globMapping is the mapping form of a glob, which excludes the files it
+// matches
+type globMapping struct {
+	Exclude string `desc:"File or glob pattern to exclude from the list."`
+}
+
 func (g *Glob) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -19,9 +26,7 @@ func (g *Glob) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var glob struct {
-			Exclude string
-		}
+		var glob globMapping
 		if err := node.Decode(&glob); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -32,3 +37,11 @@ func (g *Glob) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("glob")
 }
+
+func (g *Glob) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[globMapping](r)
+	mapping.Required = []string{"exclude"}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{jsonschema.Type("string"), mapping},
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type (
@@ -145,6 +146,26 @@ func (includes *Includes) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("includes")
 }
 
+func (includes *Includes) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:                 jsonschema.Types{"object"},
+		AdditionalProperties: jsonschema.Reflect[Include](r),
+	}
+}
+
+// includeMapping is the mapping form of an include
+type includeMapping struct {
+	Taskfile string   `desc:"The path of the Taskfile or directory to include. Relative paths are resolved from the directory of the including Taskfile."`
+	Dir      string   `desc:"The working directory of the included tasks when run."`
+	Optional bool     `desc:"If true, no errors are thrown if the Taskfile does not exist."`
+	Internal bool     `desc:"Stops the included tasks from being callable on the command line and listed with --list."`
+	Flatten  bool     `desc:"If true, the included tasks are available without a namespace."`
+	Aliases  []string `desc:"Alternative names for the namespace of the included Taskfile."`
+	Excludes []string `desc:"Tasks excluded from the inclusion."`
+	Vars     *Vars    `desc:"Variables applied to the included Taskfile."`
+	Checksum string   `desc:"The expected checksum of the included file. If it does not match, the file is not included."`
+}
+
 func (include *Include) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -157,17 +178,7 @@ func (include *Include) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var includedTaskfile struct {
-			Taskfile string
-			Dir      string
-			Optional bool
-			Internal bool
-			Flatten  bool
-			Aliases  []string
-			Excludes []string
-			Vars     *Vars
-			Checksum string
-		}
+		var includedTaskfile includeMapping
 		if err := node.Decode(&includedTaskfile); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -187,6 +198,15 @@ func (include *Include) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("include")
 }
 
+func (include *Include) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[includeMapping](r),
+		},
+	}
+}
+
 // DeepCopy creates a new instance of IncludedTaskfile and copies
 // data by value from the source struct.
 func (include *Include) DeepCopy() *Include {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type (
@@ -93,6 +94,12 @@ func (matrix *Matrix) DeepCopy() *Matrix {
 	}
 }
 
+// matrixRefMapping is the mapping form of a matrix row, which refers to a
+// variable holding its values
+type matrixRefMapping struct {
+	Ref string `desc:"Reference to a variable holding the values, like .LIST."`
+}
+
 func (matrix *Matrix) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 	case yaml.MappingNode:
@@ -118,9 +125,7 @@ func (matrix *Matrix) UnmarshalYAML(node *yaml.Node) error {
 
 			case yaml.MappingNode:
 				// Decode the value node into a Matrix struct
-				var refStruct struct {
-					Ref string
-				}
+				var refStruct matrixRefMapping
 				if err := valueNode.Decode(&refStruct); err != nil {
 					return errors.NewTaskfileDecodeError(err, node)
 				}
@@ -139,3 +144,14 @@ func (matrix *Matrix) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("matrix")
 }
+
+func (matrix *Matrix) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	ref := jsonschema.Reflect[matrixRefMapping](r)
+	ref.Required = []string{"ref"}
+	return &jsonschema.Schema{
+		Type: jsonschema.Types{"object"},
+		AdditionalProperties: &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{jsonschema.Reflect[[]any](r), ref},
+		},
+	}
+}
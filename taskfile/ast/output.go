@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Output of the Task output
@@ -19,6 +20,12 @@ func (s *Output) IsSet() bool {
 	return s.Name != ""
 }
 
+// outputMapping is the mapping form of [Output], which customizes the group
+// style
+type outputMapping struct {
+	Group *OutputGroup `desc:"Options of the group output style."`
+}
+
 func (s *Output) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -31,9 +38,7 @@ func (s *Output) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var tmp struct {
-			Group *OutputGroup
-		}
+		var tmp outputMapping
 		if err := node.Decode(&tmp); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -50,10 +55,22 @@ func (s *Output) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("output")
 }
 
+func (s *Output) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	name := jsonschema.Type("string")
+	name.Enum = []any{"interleaved", "prefixed", "group"}
+	name.Default = "interleaved"
+	mapping := jsonschema.Reflect[outputMapping](r)
+	mapping.Required = []string{"group"}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{name, mapping},
+	}
+}
+
 // OutputGroup is the style options specific to the Group style.
 type OutputGroup struct {
-	Begin, End string
-	ErrorOnly  bool `yaml:"error_only"`
+	Begin     string `desc:"Line printed before the output of a command."`
+	End       string `desc:"Line printed after the output of a command."`
+	ErrorOnly bool   `yaml:"error_only" desc:"Swallows the output of the commands that succeed."`
 }
 
 // IsSet returns true if and only if a custom output style is set.
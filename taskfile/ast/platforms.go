@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/goext"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Platform represents GOOS and GOARCH values
@@ -50,6 +51,12 @@ func (p *Platform) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("platform")
 }
 
+func (p *Platform) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	s := jsonschema.Type("string")
+	s.Description = "An OS, an architecture or both, like linux, amd64 or linux/amd64."
+	return s
+}
+
 // parsePlatform takes a string representing an OS/Arch combination (or either on their own)
 // and parses it into the Platform struct. It returns an error if the input string is invalid.
 // Valid combinations for input: OS, Arch, OS/Arch
@@ -6,6 +6,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Precondition represents a precondition necessary for a task to run
@@ -24,6 +25,12 @@ func (p *Precondition) DeepCopy() *Precondition {
 	}
 }
 
+// preconditionMapping is the mapping form of a precondition
+type preconditionMapping struct {
+	Sh  string `desc:"Command to run. If it fails, the condition fails."`
+	Msg string `desc:"Message to display when the condition fails."`
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (p *Precondition) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
@@ -38,10 +45,7 @@ func (p *Precondition) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var sh struct {
-			Sh  string
-			Msg string
-		}
+		var sh preconditionMapping
 		if err := node.Decode(&sh); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -55,3 +59,12 @@ func (p *Precondition) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("precondition")
 }
+
+func (p *Precondition) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[preconditionMapping](r),
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type Prompt []string
@@ -27,3 +28,12 @@ func (p *Prompt) UnmarshalYAML(node *yaml.Node) error {
 	}
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("prompt")
 }
+
+func (p *Prompt) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[[]string](r),
+		},
+	}
+}
@@ -1,15 +1,40 @@
 package ast
 
 import (
+	"cmp"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/jsonschema"
+)
+
+// Types of the values of a required variable
+const (
+	VarTypeString   = "string"
+	VarTypeInt      = "int"
+	VarTypeBool     = "bool"
+	VarTypePath     = "path"
+	VarTypeSemver   = "semver"
+	VarTypeDuration = "duration"
 )
 
+// varTypes are the types that a required variable can be declared with
+var varTypes = []string{VarTypeString, VarTypeInt, VarTypeBool, VarTypePath, VarTypeSemver, VarTypeDuration}
+
 // Requires represents a set of required variables necessary for a task to run
 type Requires struct {
-	Vars []*VarsWithValidation
+	Vars []*VarsWithValidation `desc:"Variables that must be set for the task to run."`
 }
 
 func (r *Requires) DeepCopy() *Requires {
@@ -25,6 +50,20 @@ func (r *Requires) DeepCopy() *Requires {
 type VarsWithValidation struct {
 	Name string
 	Enum []string
+	// Type is the type of the values, one of the VarType constants. Any value
+	// is allowed when empty.
+	Type string
+	// Pattern is a regular expression that the values must match
+	Pattern string
+	// Min and Max are the bounds of the values of int, duration and semver
+	// variables. They are empty when not set.
+	Min string
+	Max string
+	// Default is the value of the variable when it isn't set, which makes it
+	// optional
+	Default *string
+	// Location is where the variable is declared in its Taskfile
+	Location *Location `hash:"ignore"`
 }
 
 func (v *VarsWithValidation) DeepCopy() *VarsWithValidation {
@@ -32,13 +71,31 @@ func (v *VarsWithValidation) DeepCopy() *VarsWithValidation {
 		return nil
 	}
 	return &VarsWithValidation{
-		Name: v.Name,
-		Enum: v.Enum,
+		Name:     v.Name,
+		Enum:     v.Enum,
+		Type:     v.Type,
+		Pattern:  v.Pattern,
+		Min:      v.Min,
+		Max:      v.Max,
+		Default:  deepcopy.Scalar(v.Default),
+		Location: v.Location.DeepCopy(),
 	}
 }
 
+// varsWithValidationMapping is the mapping form of a required variable
+type varsWithValidationMapping struct {
+	Name    string   `desc:"Name of the variable."`
+	Enum    []string `desc:"Values allowed for the variable."`
+	Type    string   `desc:"Type of the values of the variable." enum:"string,int,bool,path,semver,duration"`
+	Pattern string   `desc:"Regular expression that the values must match."`
+	Min     string   `desc:"Minimum value of an int, duration or semver variable."`
+	Max     string   `desc:"Maximum value of an int, duration or semver variable."`
+	Default *string  `desc:"Value of the variable when it isn't set, which makes it optional."`
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (v *VarsWithValidation) UnmarshalYAML(node *yaml.Node) error {
+	v.Location = &Location{Line: node.Line, Column: node.Column}
 	switch node.Kind {
 
 	case yaml.ScalarNode:
@@ -51,17 +108,134 @@ func (v *VarsWithValidation) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 
 	case yaml.MappingNode:
-		var vv struct {
-			Name string
-			Enum []string
-		}
+		var vv varsWithValidationMapping
 		if err := node.Decode(&vv); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
 		v.Name = vv.Name
 		v.Enum = vv.Enum
+		v.Type = vv.Type
+		v.Pattern = vv.Pattern
+		v.Min = vv.Min
+		v.Max = vv.Max
+		v.Default = vv.Default
+		if err := v.check(); err != nil {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("required variable %q: %s", v.Name, err)
+		}
 		return nil
 	}
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("requires")
 }
+
+func (v *VarsWithValidation) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[varsWithValidationMapping](r)
+	mapping.Required = []string{"name"}
+	mapping.Properties["min"].Type = jsonschema.Types{"string", "integer"}
+	mapping.Properties["max"].Type = jsonschema.Types{"string", "integer"}
+	mapping.Properties["default"].Type = jsonschema.Types{"string", "number", "boolean"}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{jsonschema.Type("string"), mapping},
+	}
+}
+
+// check returns an error if the declaration of the variable is invalid
+func (v *VarsWithValidation) check() error {
+	if v.Type != "" && !slices.Contains(varTypes, v.Type) {
+		return fmt.Errorf("unknown type %q, must be one of %v", v.Type, varTypes)
+	}
+	if _, err := regexp.Compile(v.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if v.Min != "" || v.Max != "" {
+		switch v.Type {
+		case VarTypeInt, VarTypeDuration, VarTypeSemver:
+		default:
+			return errors.New("min and max only apply to the int, duration and semver types")
+		}
+	}
+	for _, bound := range []string{v.Min, v.Max} {
+		if bound == "" {
+			continue
+		}
+		if _, err := v.parse(bound); err != nil {
+			return fmt.Errorf("invalid bound %q: %w", bound, err)
+		}
+	}
+	// Defaults that use variables can only be checked at runtime, and paths
+	// may not exist yet
+	if v.Default != nil && !strings.Contains(*v.Default, "{{") {
+		if len(v.Enum) > 0 && !slices.Contains(v.Enum, *v.Default) {
+			return fmt.Errorf("invalid default %q: must be one of %v", *v.Default, v.Enum)
+		}
+		if err := v.validate(*v.Default); err != nil {
+			return fmt.Errorf("invalid default %q: %w", *v.Default, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error telling why the given value isn't valid for the
+// type, pattern and bounds of the variable. Paths are relative to the given
+// directory. The enum isn't checked.
+func (v *VarsWithValidation) Validate(value, dir string) error {
+	if v.Type == VarTypePath {
+		if _, err := os.Stat(filepathext.SmartJoin(dir, value)); err != nil {
+			return errors.New("must be an existing path")
+		}
+	}
+	return v.validate(value)
+}
+
+// validate is like Validate, without checking that paths exist
+func (v *VarsWithValidation) validate(value string) error {
+	parsed, err := v.parse(value)
+	if err != nil {
+		return fmt.Errorf("must be a valid %s", v.Type)
+	}
+	if v.Pattern != "" {
+		if matched, _ := regexp.MatchString(v.Pattern, value); !matched {
+			return fmt.Errorf("must match %q", v.Pattern)
+		}
+	}
+	if v.Min != "" {
+		if lower, _ := v.parse(v.Min); compare(parsed, lower) < 0 {
+			return fmt.Errorf("must be at least %s", v.Min)
+		}
+	}
+	if v.Max != "" {
+		if upper, _ := v.parse(v.Max); compare(parsed, upper) > 0 {
+			return fmt.Errorf("must be at most %s", v.Max)
+		}
+	}
+	return nil
+}
+
+// parse parses a value of the type of the variable. The values of the types
+// that have no parsed form are returned as is.
+func (v *VarsWithValidation) parse(value string) (any, error) {
+	switch v.Type {
+	case VarTypeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case VarTypeBool:
+		return strconv.ParseBool(value)
+	case VarTypeDuration:
+		return time.ParseDuration(value)
+	case VarTypeSemver:
+		return semver.NewVersion(value)
+	}
+	return value, nil
+}
+
+// compare compares two parsed values of an int, duration or semver variable
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case time.Duration:
+		return cmp.Compare(a, b.(time.Duration))
+	case *semver.Version:
+		return a.Compare(b.(*semver.Version))
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestVarsWithValidationParse(t *testing.T) {
+	t.Parallel()
+
+	port := "8080"
+	config := "missing.yml"
+	tests := []struct {
+		content  string
+		expected *ast.VarsWithValidation
+	}{
+		{
+			"ENV",
+			&ast.VarsWithValidation{Name: "ENV"},
+		},
+		{
+			`
+name: PORT
+type: int
+min: 1
+max: 65535
+default: 8080
+`,
+			&ast.VarsWithValidation{
+				Name:    "PORT",
+				Type:    ast.VarTypeInt,
+				Min:     "1",
+				Max:     "65535",
+				Default: &port,
+			},
+		},
+		{
+			`
+name: NAME
+pattern: ^[a-z]+$
+`,
+			&ast.VarsWithValidation{Name: "NAME", Pattern: "^[a-z]+$"},
+		},
+		{
+			"{name: CONFIG, type: path, default: missing.yml}",
+			&ast.VarsWithValidation{Name: "CONFIG", Type: ast.VarTypePath, Default: &config},
+		},
+	}
+	for _, test := range tests {
+		var v ast.VarsWithValidation
+		require.NoError(t, yaml.Unmarshal([]byte(test.content), &v))
+		v.Location = nil
+		assert.Equal(t, test.expected, &v)
+	}
+}
+
+func TestVarsWithValidationParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"{name: X, type: float}", `unknown type "float"`},
+		{"{name: X, pattern: '['}", "invalid pattern"},
+		{"{name: X, type: bool, min: 1}", "min and max only apply"},
+		{"{name: X, min: 1}", "min and max only apply"},
+		{"{name: X, type: duration, max: 10}", `invalid bound "10"`},
+		{"{name: X, type: int, default: abc}", `invalid default "abc": must be a valid int`},
+		{"{name: X, type: int, max: 10, default: 11}", `invalid default "11": must be at most 10`},
+		{"{name: X, pattern: '^[a-z]+$', default: ABC}", `invalid default "ABC": must match`},
+		{"{name: X, enum: [a, b], default: c}", `invalid default "c": must be one of [a b]`},
+	}
+	for _, test := range tests {
+		var v ast.VarsWithValidation
+		err := yaml.Unmarshal([]byte(test.content), &v)
+		require.Error(t, err, test.content)
+		assert.Contains(t, err.Error(), test.err, test.content)
+	}
+}
+
+func TestVarsWithValidationValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		v     ast.VarsWithValidation
+		value string
+		err   string
+	}{
+		{ast.VarsWithValidation{}, "anything", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypeInt}, "42", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypeInt}, "4.2", "must be a valid int"},
+		{ast.VarsWithValidation{Type: ast.VarTypeInt, Min: "1", Max: "10"}, "0", "must be at least 1"},
+		{ast.VarsWithValidation{Type: ast.VarTypeInt, Min: "1", Max: "10"}, "11", "must be at most 10"},
+		{ast.VarsWithValidation{Type: ast.VarTypeBool}, "true", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypeBool}, "yes", "must be a valid bool"},
+		{ast.VarsWithValidation{Type: ast.VarTypeDuration, Max: "1m"}, "30s", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypeDuration, Max: "1m"}, "2m", "must be at most 1m"},
+		{ast.VarsWithValidation{Type: ast.VarTypeSemver, Min: "1.2.0"}, "v1.10.0", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypeSemver, Min: "1.2.0"}, "1.1.9", "must be at least 1.2.0"},
+		{ast.VarsWithValidation{Type: ast.VarTypeSemver}, "latest", "must be a valid semver"},
+		{ast.VarsWithValidation{Type: ast.VarTypePath}, "requires.go", ""},
+		{ast.VarsWithValidation{Type: ast.VarTypePath}, "missing.go", "must be an existing path"},
+		{ast.VarsWithValidation{Pattern: "^[a-z]+$"}, "Api", `must match "^[a-z]+$"`},
+	}
+	for _, test := range tests {
+		err := test.v.Validate(test.value, ".")
+		if test.err == "" {
+			assert.NoError(t, err, test.value)
+		} else {
+			assert.EqualError(t, err, test.err, test.value)
+		}
+	}
+}
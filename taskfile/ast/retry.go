@@ -0,0 +1,137 @@
+package ast
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
+)
+
+// Backoff strategies that can be used by a [Retry] policy
+const (
+	BackoffFixed       = "fixed"
+	BackoffExponential = "exponential"
+)
+
+// Retry represents the policy used to retry a command that failed
+type Retry struct {
+	// Count is the number of times a command is retried after the first
+	// failed attempt.
+	Count int
+	// Backoff is the strategy used to compute the delay between attempts.
+	// It is either [BackoffFixed] (the default) or [BackoffExponential].
+	Backoff string
+	// Delay is the time to wait before the first retry.
+	Delay time.Duration
+	// MaxDelay caps the delay between attempts when using exponential
+	// backoff. Zero means no limit.
+	MaxDelay time.Duration
+	// ExitCodes restricts retries to commands that exited with one of the
+	// given codes. When empty, any failure is retried.
+	ExitCodes []int
+}
+
+func (r *Retry) DeepCopy() *Retry {
+	if r == nil {
+		return nil
+	}
+	return &Retry{
+		Count:     r.Count,
+		Backoff:   r.Backoff,
+		Delay:     r.Delay,
+		MaxDelay:  r.MaxDelay,
+		ExitCodes: slices.Clone(r.ExitCodes),
+	}
+}
+
+// DelayFor returns the time to wait before the given retry attempt. The first
+// retry is attempt 1.
+func (r *Retry) DelayFor(attempt int) time.Duration {
+	if r.Backoff != BackoffExponential || attempt <= 1 {
+		return r.Delay
+	}
+	delay := r.Delay
+	for range attempt - 1 {
+		// Without a maximum, the delay stops growing before it overflows
+		if delay > math.MaxInt64/2 {
+			return math.MaxInt64
+		}
+		delay *= 2
+		if r.MaxDelay > 0 && delay >= r.MaxDelay {
+			return r.MaxDelay
+		}
+	}
+	return delay
+}
+
+// RetriesExitCode returns true if a command that exited with the given code
+// should be retried.
+func (r *Retry) RetriesExitCode(code int) bool {
+	return len(r.ExitCodes) == 0 || slices.Contains(r.ExitCodes, code)
+}
+
+// retryMapping is the mapping form of a [Retry] policy
+type retryMapping struct {
+	Count     int            `desc:"Number of times a failed command is retried." minimum:"0"`
+	Backoff   string         `desc:"Strategy used to compute the delay between attempts." enum:"fixed,exponential" default:"fixed"`
+	Delay     *time.Duration `desc:"Time to wait before the first retry (e.g. 500ms, 2s)." default:"1s"`
+	MaxDelay  time.Duration  `yaml:"max_delay" desc:"Maximum delay between attempts when using exponential backoff."`
+	ExitCodes []int          `yaml:"exit_codes" desc:"Only retries the commands that exited with one of these codes."`
+}
+
+func (r *Retry) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+
+	// Shortcut syntax for a number of retries
+	case yaml.ScalarNode:
+		var count int
+		if err := node.Decode(&count); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		if count < 0 {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("retry count cannot be negative")
+		}
+		r.Count = count
+		r.Delay = time.Second
+		return nil
+
+	case yaml.MappingNode:
+		var retry retryMapping
+		if err := node.Decode(&retry); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		if retry.Count < 0 {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("retry count cannot be negative")
+		}
+		switch retry.Backoff {
+		case "", BackoffFixed, BackoffExponential:
+		default:
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage(`retry backoff must be "fixed" or "exponential"`)
+		}
+		r.Count = retry.Count
+		r.Backoff = retry.Backoff
+		r.Delay = time.Second
+		if retry.Delay != nil {
+			r.Delay = *retry.Delay
+		}
+		r.MaxDelay = retry.MaxDelay
+		r.ExitCodes = retry.ExitCodes
+		return nil
+	}
+
+	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("retry")
+}
+
+func (r *Retry) JSONSchema(reflector *jsonschema.Reflector) *jsonschema.Schema {
+	zero := 0
+	count := jsonschema.Type("integer")
+	count.Description = "Number of times a failed command is retried, waiting 1 second between attempts."
+	count.Minimum = &zero
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{count, jsonschema.Reflect[retryMapping](reflector)},
+	}
+}
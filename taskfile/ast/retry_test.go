@@ -0,0 +1,95 @@
+package ast_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestRetryParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content  string
+		expected *ast.Retry
+	}{
+		{
+			"3",
+			&ast.Retry{Count: 3, Delay: time.Second},
+		},
+		{
+			`
+count: 2
+backoff: exponential
+delay: 100ms
+max_delay: 1s
+exit_codes: [1, 75]
+`,
+			&ast.Retry{
+				Count:     2,
+				Backoff:   ast.BackoffExponential,
+				Delay:     100 * time.Millisecond,
+				MaxDelay:  time.Second,
+				ExitCodes: []int{1, 75},
+			},
+		},
+		{
+			`
+count: 1
+delay: 0s
+`,
+			&ast.Retry{Count: 1},
+		},
+	}
+	for _, test := range tests {
+		var retry ast.Retry
+		err := yaml.Unmarshal([]byte(test.content), &retry)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, &retry)
+	}
+}
+
+func TestRetryParseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{
+		"-1",
+		"count: -1",
+		"backoff: linear",
+	} {
+		var retry ast.Retry
+		require.Error(t, yaml.Unmarshal([]byte(content), &retry))
+	}
+
+	var cmd ast.Cmd
+	err := yaml.Unmarshal([]byte("{task: build, retry: 2}"), &cmd)
+	require.ErrorContains(t, err, "retry cannot be used when calling a task")
+}
+
+func TestRetryDelayFor(t *testing.T) {
+	t.Parallel()
+
+	fixed := &ast.Retry{Count: 3, Delay: time.Second}
+	assert.Equal(t, time.Second, fixed.DelayFor(1))
+	assert.Equal(t, time.Second, fixed.DelayFor(3))
+
+	exponential := &ast.Retry{
+		Count:    5,
+		Backoff:  ast.BackoffExponential,
+		Delay:    time.Second,
+		MaxDelay: 5 * time.Second,
+	}
+	assert.Equal(t, time.Second, exponential.DelayFor(1))
+	assert.Equal(t, 2*time.Second, exponential.DelayFor(2))
+	assert.Equal(t, 4*time.Second, exponential.DelayFor(3))
+	assert.Equal(t, 5*time.Second, exponential.DelayFor(4))
+
+	unbounded := &ast.Retry{Count: 100, Backoff: ast.BackoffExponential, Delay: time.Second}
+	assert.Equal(t, time.Duration(math.MaxInt64), unbounded.DelayFor(100))
+}
@@ -0,0 +1,22 @@
+package ast
+
+import "github.com/go-task/task/v3/internal/jsonschema"
+
+// versionPattern matches the semantic versions that Taskfiles can declare,
+// with an optional minor and patch version
+const versionPattern = `^(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:\.(0|[1-9]\d*))?(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+
+// Schema returns the JSON Schema of Taskfiles, built from the types they are
+// decoded into.
+func Schema() *jsonschema.Schema {
+	s := jsonschema.Document[taskfileMapping]("Taskfile YAML Schema", "Schema for Taskfile files.")
+	s.Required = []string{"version"}
+	// The version is either a string or the number 3
+	version := s.Properties["version"]
+	version.Type = nil
+	version.OneOf = []*jsonschema.Schema{
+		{Type: jsonschema.Types{"string"}, Pattern: versionPattern},
+		{Type: jsonschema.Types{"number"}, Enum: []any{3}},
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestSchemaIsPublished(t *testing.T) {
+	t.Parallel()
+
+	published, err := os.ReadFile("../../website/src/public/schema.json")
+	require.NoError(t, err)
+	generated, err := json.Marshal(ast.Schema())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(published), string(generated), "the published schema is outdated, run `task generate:schema`")
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := ast.Schema()
+	assert.Equal(t, []string{"version"}, schema.Required)
+	assert.Equal(t, false, schema.AdditionalProperties)
+	assert.Equal(t, "#/definitions/tasks", schema.Properties["tasks"].Ref)
+
+	// Every shape accepted by the decoders of these types is described
+	for _, name := range []string{"cmd", "dep", "for", "matrix", "include", "platform", "prompt", "defer", "vars_with_validation"} {
+		assert.Contains(t, schema.Definitions, name)
+	}
+	cmd := schema.Definitions["cmd"]
+	require.Len(t, cmd.AnyOf, 2)
+	mapping := cmd.AnyOf[1]
+	assert.Contains(t, mapping.Properties, "ignore_error")
+	assert.Equal(t, "#/definitions/for", mapping.Properties["for"].Ref)
+	assert.Contains(t, mapping.Properties["shopt"].Items.Enum, "globstar")
+
+	version := schema.Properties["version"]
+	require.Len(t, version.OneOf, 2)
+	assert.Regexp(t, version.OneOf[0].Pattern, "3.42.1")
+	assert.NotRegexp(t, version.OneOf[0].Pattern, "latest")
+	assert.Equal(t, []any{3}, version.OneOf[1].Enum)
+	assert.Equal(t, []any{"sources", "generates"}, schema.Definitions["for"].AnyOf[0].Enum)
+
+	// Custom fingerprint methods can be registered, so the built-in ones are
+	// only suggested
+	method := schema.Properties["method"]
+	assert.Empty(t, method.Enum)
+	assert.Contains(t, method.Examples, "checksum")
+}
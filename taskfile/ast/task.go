@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Task represents a task
@@ -36,14 +38,20 @@ type Task struct {
 	Interactive   bool
 	Internal      bool
 	Method        string
+	Fingerprint   string
+	Track         *Track
 	Prefix        string `hash:"ignore"`
 	IgnoreError   bool
 	Run           string
 	Platforms     []*Platform
 	If            string
 	Watch         bool
+	WatchOptions  *WatchOptions
 	Location      *Location
 	Failfast      bool
+	Timeout       time.Duration
+	Retry         *Retry
+	Cache         bool
 	// Populated during merging
 	Namespace            string `hash:"ignore"`
 	IncludeVars          *Vars
@@ -102,6 +110,45 @@ func (t *Task) WildcardMatch(name string) (bool, []string) {
 	return false, nil
 }
 
+// taskMapping is the mapping form of a task, with all its attributes
+type taskMapping struct {
+	Cmds          []*Cmd          `desc:"A list of commands to be executed."`
+	Cmd           *Cmd            `desc:"The command to be executed."`
+	Deps          []*Dep          `desc:"A list of dependencies of this task. Tasks defined here will run in parallel before this task."`
+	Label         string          `desc:"Overrides the name of the task in the output when a task is run. Supports variables."`
+	Desc          string          `desc:"A short description of the task. This is displayed when calling task --list."`
+	Prompt        Prompt          `desc:"One or more prompts that will be presented before a task is run. Declining will cancel running the current and any subsequent tasks."`
+	Summary       string          `desc:"A longer description of the task. This is displayed when calling task --summary [task]."`
+	Aliases       []string        `desc:"A list of alternative names by which the task can be called."`
+	Sources       []*Glob         `desc:"A list of sources to check before running this task. Relevant for checksum and timestamp methods. Can be file paths or star globs."`
+	Generates     []*Glob         `desc:"A list of files meant to be generated by this task. Relevant for timestamp method. Can be file paths or star globs."`
+	Status        []string        `desc:"A list of commands to check if this task should run. The task is skipped otherwise. This overrides method, sources and generates."`
+	Preconditions []*Precondition `desc:"A list of commands to check if this task should run. If a condition is not met, the task will error."`
+	Dir           string          `desc:"The directory in which this task should run. Defaults to the current working directory."`
+	Set           []string        `desc:"Enables POSIX shell options for all of a task's commands. See https://www.gnu.org/software/bash/manual/html_node/The-Set-Builtin.html" enum:"allexport,a,errexit,e,noexec,n,noglob,f,nounset,u,xtrace,x,pipefail"`
+	Shopt         []string        `desc:"Enables Bash shell options for all of a task's commands. See https://www.gnu.org/software/bash/manual/html_node/The-Shopt-Builtin.html" enum:"expand_aliases,globstar,nullglob"`
+	Vars          *Vars           `desc:"A set of variables that can be used in the task."`
+	Env           *Vars           `desc:"A set of environment variables that will be made available to shell commands."`
+	Dotenv        []string        `desc:"A list of .env file paths to be parsed."`
+	Silent        *bool           `yaml:"silent,omitempty" desc:"Hides task name and command from output. The command's output will still be redirected to STDOUT and STDERR. When combined with the --list flag, task descriptions will be hidden." default:"false"`
+	Interactive   bool            `desc:"Tells task that the command is interactive." default:"false"`
+	Internal      bool            `desc:"Stops a task from being callable on the command line. It will also be omitted from the output when used with --list." default:"false"`
+	Method        string          `desc:"Defines which method is used to check the task is up-to-date: timestamp, checksum, git, exec, none or a custom method." examples:"none,checksum,timestamp,git,exec"`
+	Fingerprint   string          `desc:"A command that prints the fingerprint of the task's inputs. Used by the exec method, which runs the task again when the output changes."`
+	Track         *Track          `desc:"Variables and environment variables that are part of the fingerprint of the task, so that it runs again when their values change."`
+	Prefix        string          `desc:"Defines a string to prefix the output of tasks running in parallel. Only used when the output mode is prefixed."`
+	IgnoreError   bool            `yaml:"ignore_error" desc:"Continue execution if errors happen while executing commands."`
+	Run           string          `desc:"Specifies whether the task should run again or not if called more than once." enum:"always,once,when_changed"`
+	Platforms     []*Platform     `desc:"Specifies which platforms the task should be run on."`
+	If            string          `desc:"A shell command to evaluate. If the exit code is non-zero, the task is skipped."`
+	Requires      *Requires       `desc:"Variables that must be set for the task to run."`
+	Watch         watchValue      `desc:"Configures a task to run in watch mode automatically."`
+	Failfast      bool            `desc:"When running tasks in parallel, stop all tasks if one fails." default:"false"`
+	Timeout       time.Duration   `desc:"Maximum duration the task's commands may run for (e.g. 30s, 5m), not counting its dependencies. When exceeded, running commands are interrupted and then killed after a grace period."`
+	Retry         *Retry          `desc:"Retry policy applied to every command of the task that doesn't define its own."`
+	Cache         bool            `desc:"Stores the files listed in generates in a local cache after the task runs, and restores them instead of running the task again when its sources, definition and variables match a previous run." default:"false"`
+}
+
 func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 
@@ -125,38 +172,7 @@ func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 
 	// Full task object
 	case yaml.MappingNode:
-		var task struct {
-			Cmds          []*Cmd
-			Cmd           *Cmd
-			Deps          []*Dep
-			Label         string
-			Desc          string
-			Prompt        Prompt
-			Summary       string
-			Aliases       []string
-			Sources       []*Glob
-			Generates     []*Glob
-			Status        []string
-			Preconditions []*Precondition
-			Dir           string
-			Set           []string
-			Shopt         []string
-			Vars          *Vars
-			Env           *Vars
-			Dotenv        []string
-			Silent        *bool `yaml:"silent,omitempty"`
-			Interactive   bool
-			Internal      bool
-			Method        string
-			Prefix        string
-			IgnoreError   bool `yaml:"ignore_error"`
-			Run           string
-			Platforms     []*Platform
-			If            string
-			Requires      *Requires
-			Watch         bool
-			Failfast      bool
-		}
+		var task taskMapping
 		if err := node.Decode(&task); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -188,20 +204,38 @@ func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 		t.Interactive = task.Interactive
 		t.Internal = task.Internal
 		t.Method = task.Method
+		t.Fingerprint = task.Fingerprint
+		t.Track = task.Track
 		t.Prefix = task.Prefix
 		t.IgnoreError = task.IgnoreError
 		t.Run = task.Run
 		t.Platforms = task.Platforms
 		t.If = task.If
 		t.Requires = task.Requires
-		t.Watch = task.Watch
+		t.Watch = task.Watch.enabled
+		t.WatchOptions = task.Watch.options
 		t.Failfast = task.Failfast
+		t.Timeout = task.Timeout
+		t.Retry = task.Retry
+		t.Cache = task.Cache
 		return nil
 	}
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("task")
 }
 
+func (t *Task) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[taskMapping](r)
+	mapping.Not = &jsonschema.Schema{Required: []string{"cmd", "cmds"}}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("string"),
+			jsonschema.Reflect[[]*Cmd](r),
+			mapping,
+		},
+	}
+}
+
 // DeepCopy creates a new instance of Task and copies
 // data by value from the source struct.
 func (t *Task) DeepCopy() *Task {
@@ -231,6 +265,8 @@ func (t *Task) DeepCopy() *Task {
 		Interactive:          t.Interactive,
 		Internal:             t.Internal,
 		Method:               t.Method,
+		Fingerprint:          t.Fingerprint,
+		Track:                t.Track.DeepCopy(),
 		Prefix:               t.Prefix,
 		IgnoreError:          t.IgnoreError,
 		Run:                  t.Run,
@@ -240,9 +276,14 @@ func (t *Task) DeepCopy() *Task {
 		If:                   t.If,
 		Location:             t.Location.DeepCopy(),
 		Requires:             t.Requires.DeepCopy(),
+		Watch:                t.Watch,
+		WatchOptions:         t.WatchOptions.DeepCopy(),
 		Namespace:            t.Namespace,
 		FullName:             t.FullName,
 		Failfast:             t.Failfast,
+		Timeout:              t.Timeout,
+		Retry:                t.Retry.DeepCopy(),
+		Cache:                t.Cache,
 	}
 	return c
 }
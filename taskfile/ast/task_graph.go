@@ -0,0 +1,71 @@
+package ast
+
+import (
+	"github.com/dominikbraun/graph"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// Kinds of [TaskReference]s between the tasks of a [TaskGraph].
+const (
+	TaskEdgeDep = "dep"
+	TaskEdgeCmd = "cmd"
+)
+
+// TaskGraph is a directed graph of tasks. An edge from one task to another
+// means that the first task calls the second, either as one of its deps or
+// from one of its cmds. Each edge holds the [TaskReference] as its data.
+// Unlike [TaskfileGraph], it may contain cycles.
+type TaskGraph struct {
+	graph.Graph[string, *Task]
+}
+
+// A TaskReference is the place where a task calls another one.
+type TaskReference struct {
+	// Kind is either [TaskEdgeDep] or [TaskEdgeCmd]
+	Kind     string
+	Location *Location
+}
+
+func taskHash(t *Task) string {
+	return t.Task
+}
+
+func NewTaskGraph() *TaskGraph {
+	return &TaskGraph{
+		graph.New(taskHash, graph.Directed()),
+	}
+}
+
+// AddTask adds the given task to the graph, unless it is already there.
+func (tg *TaskGraph) AddTask(t *Task) error {
+	if err := tg.AddVertex(t); err != nil && !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// AddReference adds an edge from one task to another. If the first task
+// already references the second, the existing edge is kept.
+func (tg *TaskGraph) AddReference(from, to *Task, ref *TaskReference) error {
+	for _, t := range []*Task{from, to} {
+		if err := tg.AddTask(t); err != nil {
+			return err
+		}
+	}
+	if err := tg.AddEdge(from.Task, to.Task, graph.EdgeData(ref)); err != nil && !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// Reference returns the reference from one task to another, or nil if the
+// first task doesn't call the second.
+func (tg *TaskGraph) Reference(from, to string) *TaskReference {
+	edge, err := tg.Edge(from, to)
+	if err != nil {
+		return nil
+	}
+	ref, _ := edge.Properties.Data.(*TaskReference)
+	return ref
+}
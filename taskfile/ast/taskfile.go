@@ -72,24 +72,27 @@ func (t1 *Taskfile) Merge(t2 *Taskfile, include *Include) error {
 	return t1.Tasks.Merge(t2.Tasks, include, t1.Vars)
 }
 
+// taskfileMapping is the mapping a Taskfile is decoded from
+type taskfileMapping struct {
+	Version  *semver.Version `desc:"Specifies the Taskfile format that this file conforms to."`
+	Output   Output          `desc:"Defines how the STDOUT and STDERR are printed when running tasks in parallel: interleaved (default), group or prefixed."`
+	Method   string          `desc:"Defines which method is used to check the task is up-to-date." examples:"none,checksum,timestamp,git,exec" default:"checksum"`
+	Includes *Includes       `desc:"Imports tasks from the specified Taskfiles. The tasks described in the given Taskfiles will be available with the informed namespace."`
+	Set      []string        `desc:"Enables POSIX shell options for all commands in the Taskfile. See https://www.gnu.org/software/bash/manual/html_node/The-Set-Builtin.html" enum:"allexport,a,errexit,e,noexec,n,noglob,f,nounset,u,xtrace,x,pipefail"`
+	Shopt    []string        `desc:"Enables Bash shell options for all commands in the Taskfile. See https://www.gnu.org/software/bash/manual/html_node/The-Shopt-Builtin.html" enum:"expand_aliases,globstar,nullglob"`
+	Vars     *Vars           `desc:"A set of global variables."`
+	Env      *Vars           `desc:"A set of global environment variables."`
+	Tasks    *Tasks          `desc:"A set of task definitions."`
+	Silent   bool            `desc:"Default silent option for this Taskfile. If false, can be overridden with true in a task by task basis."`
+	Dotenv   []string        `desc:"A list of .env file paths to be parsed."`
+	Run      string          `desc:"Default run option for this Taskfile." enum:"always,once,when_changed"`
+	Interval time.Duration   `desc:"Sets a different watch interval when using --watch, the default being 100 milliseconds. This string should be a valid Go duration: https://pkg.go.dev/time#ParseDuration."`
+}
+
 func (tf *Taskfile) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 	case yaml.MappingNode:
-		var taskfile struct {
-			Version  *semver.Version
-			Output   Output
-			Method   string
-			Includes *Includes
-			Set      []string
-			Shopt    []string
-			Vars     *Vars
-			Env      *Vars
-			Tasks    *Tasks
-			Silent   bool
-			Dotenv   []string
-			Run      string
-			Interval time.Duration
-		}
+		var taskfile taskfileMapping
 		if err := node.Decode(&taskfile); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
@@ -33,7 +33,7 @@ vars:
 		{
 			yamlCmd,
 			&ast.Cmd{},
-			&ast.Cmd{Cmd: `echo "a string command"`},
+			&ast.Cmd{Cmd: `echo "a string command"`, Location: &ast.Location{Line: 1, Column: 1}},
 		},
 		{
 			yamlTaskCall,
@@ -54,12 +54,13 @@ vars:
 						},
 					},
 				),
+				Location: &ast.Location{Line: 2, Column: 1},
 			},
 		},
 		{
 			yamlDeferredCmd,
 			&ast.Cmd{},
-			&ast.Cmd{Cmd: "echo 'test'", Defer: true},
+			&ast.Cmd{Cmd: "echo 'test'", Defer: true, Location: &ast.Location{Line: 1, Column: 1}},
 		},
 		{
 			yamlDeferredCall,
@@ -74,13 +75,14 @@ vars:
 						},
 					},
 				),
-				Defer: true,
+				Defer:    true,
+				Location: &ast.Location{Line: 1, Column: 1},
 			},
 		},
 		{
 			yamlDep,
 			&ast.Dep{},
-			&ast.Dep{Task: "task-name"},
+			&ast.Dep{Task: "task-name", Location: &ast.Location{Line: 1, Column: 1}},
 		},
 		{
 			yamlTaskCall,
@@ -101,6 +103,7 @@ vars:
 						},
 					},
 				),
+				Location: &ast.Location{Line: 2, Column: 1},
 			},
 		},
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/jsonschema"
 	"github.com/go-task/task/v3/internal/sort"
 )
 
@@ -243,6 +244,13 @@ func (t *Tasks) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("tasks")
 }
 
+func (t *Tasks) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:                 jsonschema.Types{"object"},
+		AdditionalProperties: jsonschema.Reflect[Task](r),
+	}
+}
+
 func taskNameWithNamespace(taskName string, namespace string) string {
 	if after, ok := strings.CutPrefix(taskName, NamespaceSeparator); ok {
 		return after
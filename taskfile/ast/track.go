@@ -0,0 +1,76 @@
+package ast
+
+import (
+	"slices"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
+)
+
+// Track lists the variables and environment variables that are part of the
+// fingerprint of a task, so that the task runs again when their values change
+type Track struct {
+	// All is true if every variable and environment variable declared in the
+	// Taskfiles or given on the command line is tracked.
+	All bool
+	// Vars are the names of the tracked variables.
+	Vars []string
+	// Env are the names of the tracked environment variables.
+	Env []string
+}
+
+func (t *Track) DeepCopy() *Track {
+	if t == nil {
+		return nil
+	}
+	return &Track{
+		All:  t.All,
+		Vars: slices.Clone(t.Vars),
+		Env:  slices.Clone(t.Env),
+	}
+}
+
+// trackMapping is the mapping form of [Track], which lists the tracked names
+type trackMapping struct {
+	Vars []string `desc:"Names of the tracked variables."`
+	Env  []string `desc:"Names of the tracked environment variables."`
+}
+
+func (t *Track) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+
+	// Shortcut syntax to track everything
+	case yaml.ScalarNode:
+		var all string
+		if err := node.Decode(&all); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		if all != "all" {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage(`track must be "all" or a map of vars and env`)
+		}
+		t.All = true
+		return nil
+
+	case yaml.MappingNode:
+		var track trackMapping
+		if err := node.Decode(&track); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		t.Vars = track.Vars
+		t.Env = track.Env
+		return nil
+	}
+
+	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("track")
+}
+
+func (t *Track) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	all := jsonschema.Type("string")
+	all.Description = "Tracks every variable and environment variable declared in the Taskfiles or given on the command line."
+	all.Enum = []any{"all"}
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{all, jsonschema.Reflect[trackMapping](r)},
+	}
+}
@@ -0,0 +1,45 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestTrackParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content  string
+		expected *ast.Track
+	}{
+		{
+			"all",
+			&ast.Track{All: true},
+		},
+		{
+			`
+vars: [GOOS, GOARCH]
+env: [CGO_ENABLED]
+`,
+			&ast.Track{
+				Vars: []string{"GOOS", "GOARCH"},
+				Env:  []string{"CGO_ENABLED"},
+			},
+		},
+	}
+	for _, test := range tests {
+		var track ast.Track
+		require.NoError(t, yaml.Unmarshal([]byte(test.content), &track))
+		assert.Equal(t, test.expected, &track)
+	}
+
+	for _, content := range []string{"some", "[GOOS]"} {
+		var track ast.Track
+		require.Error(t, yaml.Unmarshal([]byte(content), &track), content)
+	}
+}
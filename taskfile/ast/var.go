@@ -4,6 +4,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 // Var represents either a static or dynamic variable.
@@ -15,6 +16,14 @@ type Var struct {
 	Dir   string
 }
 
+// varMapping is the mapping form of a variable, which is set by running a
+// command, referring to another variable or given a map
+type varMapping struct {
+	Sh  *string `desc:"Command whose output is assigned to the variable."`
+	Ref string  `desc:"Reference to another variable whose value is assigned to the variable."`
+	Map any     `desc:"Map assigned to the variable as is."`
+}
+
 func (v *Var) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 	case yaml.MappingNode:
@@ -24,11 +33,7 @@ func (v *Var) UnmarshalYAML(node *yaml.Node) error {
 		}
 		switch key {
 		case "sh", "ref", "map":
-			var m struct {
-				Sh  *string
-				Ref string
-				Map any
-			}
+			var m varMapping
 			if err := node.Decode(&m); err != nil {
 				return errors.NewTaskfileDecodeError(err, node)
 			}
@@ -48,3 +53,18 @@ func (v *Var) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 	}
 }
+
+func (v *Var) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	mapping := jsonschema.Reflect[varMapping](r)
+	mapping.OneOf = []*jsonschema.Schema{
+		{Required: []string{"sh"}},
+		{Required: []string{"ref"}},
+		{Required: []string{"map"}},
+	}
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			jsonschema.Type("boolean", "integer", "null", "number", "string", "array"),
+			mapping,
+		},
+	}
+}
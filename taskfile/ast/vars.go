@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/jsonschema"
 )
 
 type (
@@ -201,3 +202,10 @@ func (vs *Vars) UnmarshalYAML(node *yaml.Node) error {
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("vars")
 }
+
+func (vs *Vars) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:                 jsonschema.Types{"object"},
+		AdditionalProperties: jsonschema.Reflect[Var](r),
+	}
+}
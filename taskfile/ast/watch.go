@@ -0,0 +1,96 @@
+package ast
+
+import (
+	"time"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/jsonschema"
+)
+
+// Strategies that can be used by [WatchOptions] when the sources of a watched
+// task change while it is still running
+const (
+	WatchRestart            = "restart"
+	WatchQueue              = "queue"
+	WatchIgnoreWhileRunning = "ignore-while-running"
+)
+
+// WatchOptions configures how a watched task runs again when its sources
+// change
+type WatchOptions struct {
+	// Strategy tells what to do with changes that happen while the task is
+	// running. It is either [WatchRestart] (the default), [WatchQueue] or
+	// [WatchIgnoreWhileRunning].
+	Strategy string
+	// Debounce is the time to wait for more changes before running the task.
+	// Zero means the interval of the Taskfile is used.
+	Debounce time.Duration
+}
+
+func (w *WatchOptions) DeepCopy() *WatchOptions {
+	if w == nil {
+		return nil
+	}
+	return &WatchOptions{
+		Strategy: w.Strategy,
+		Debounce: w.Debounce,
+	}
+}
+
+// watchValue is the value of the watch key of a task, which is either a
+// boolean or the watch options of a task that is always watched
+type watchValue struct {
+	enabled bool
+	options *WatchOptions
+}
+
+// watchMapping is the mapping form of the watch key of a task
+type watchMapping struct {
+	Strategy string        `desc:"What to do when the sources change while the task is running: restart it, queue another run or ignore-while-running." enum:"restart,queue,ignore-while-running" default:"restart"`
+	Debounce time.Duration `desc:"Time to wait for more changes before running the task (e.g. 500ms). Defaults to the interval of the Taskfile."`
+}
+
+func (w *watchValue) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+
+	case yaml.ScalarNode:
+		if err := node.Decode(&w.enabled); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		return nil
+
+	case yaml.MappingNode:
+		var watch watchMapping
+		if err := node.Decode(&watch); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		switch watch.Strategy {
+		case "", WatchRestart, WatchQueue, WatchIgnoreWhileRunning:
+		default:
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage(`watch strategy must be "restart", "queue" or "ignore-while-running"`)
+		}
+		if watch.Debounce < 0 {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("watch debounce cannot be negative")
+		}
+		w.enabled = true
+		w.options = &WatchOptions{
+			Strategy: watch.Strategy,
+			Debounce: watch.Debounce,
+		}
+		return nil
+	}
+
+	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("watch")
+}
+
+func (w *watchValue) JSONSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	enabled := jsonschema.Type("boolean")
+	enabled.Default = false
+	mapping := jsonschema.Reflect[watchMapping](r)
+	mapping.Description = "Runs the task in watch mode automatically, with the given options."
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{enabled, mapping},
+	}
+}
@@ -0,0 +1,62 @@
+package ast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestWatchParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content  string
+		watch    bool
+		expected *ast.WatchOptions
+	}{
+		{"watch: true", true, nil},
+		{"watch: false", false, nil},
+		{
+			`
+watch:
+  strategy: ignore-while-running
+  debounce: 500ms
+`,
+			true,
+			&ast.WatchOptions{Strategy: ast.WatchIgnoreWhileRunning, Debounce: 500 * time.Millisecond},
+		},
+		{
+			`
+watch:
+  strategy: queue
+`,
+			true,
+			&ast.WatchOptions{Strategy: ast.WatchQueue},
+		},
+	}
+	for _, test := range tests {
+		var task ast.Task
+		err := yaml.Unmarshal([]byte(test.content), &task)
+		require.NoError(t, err)
+		assert.Equal(t, test.watch, task.Watch)
+		assert.Equal(t, test.expected, task.WatchOptions)
+	}
+}
+
+func TestWatchParseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{
+		"watch: [true]",
+		"watch:\n  strategy: later\n",
+		"watch:\n  debounce: -1s\n",
+	} {
+		var task ast.Task
+		require.Error(t, yaml.Unmarshal([]byte(content), &task))
+	}
+}
@@ -24,9 +24,9 @@ type HTTPNode struct {
 	client *http.Client // HTTP client with optional TLS configuration
 }
 
-// buildHTTPClient creates an HTTP client with optional TLS configuration.
+// NewHTTPClient creates an HTTP client with optional TLS configuration.
 // If no certificate options are provided, it returns http.DefaultClient.
-func buildHTTPClient(insecure bool, caCert, cert, certKey string) (*http.Client, error) {
+func NewHTTPClient(insecure bool, caCert, cert, certKey string) (*http.Client, error) {
 	// Validate that cert and certKey are provided together
 	if (cert != "" && certKey == "") || (cert == "" && certKey != "") {
 		return nil, fmt.Errorf("both --cert and --cert-key must be provided together")
@@ -85,7 +85,7 @@ func NewHTTPNode(
 		return nil, &errors.TaskfileNotSecureError{URI: url.Redacted()}
 	}
 
-	client, err := buildHTTPClient(insecure, base.caCert, base.cert, base.certKey)
+	client, err := NewHTTPClient(insecure, base.caCert, base.cert, base.certKey)
 	if err != nil {
 		return nil, err
 	}
@@ -59,19 +59,19 @@ func TestHTTPNode_CacheKey(t *testing.T) {
 	}
 }
 
-func TestBuildHTTPClient_Default(t *testing.T) {
+func TestNewHTTPClient_Default(t *testing.T) {
 	t.Parallel()
 
 	// When no TLS customization is needed, should return http.DefaultClient
-	client, err := buildHTTPClient(false, "", "", "")
+	client, err := NewHTTPClient(false, "", "", "")
 	require.NoError(t, err)
 	assert.Equal(t, http.DefaultClient, client)
 }
 
-func TestBuildHTTPClient_Insecure(t *testing.T) {
+func TestNewHTTPClient_Insecure(t *testing.T) {
 	t.Parallel()
 
-	client, err := buildHTTPClient(true, "", "", "")
+	client, err := NewHTTPClient(true, "", "", "")
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	assert.NotEqual(t, http.DefaultClient, client)
@@ -83,7 +83,7 @@ func TestBuildHTTPClient_Insecure(t *testing.T) {
 	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
 }
 
-func TestBuildHTTPClient_CACert(t *testing.T) {
+func TestNewHTTPClient_CACert(t *testing.T) {
 	t.Parallel()
 
 	// Create a temporary CA cert file
@@ -95,7 +95,7 @@ func TestBuildHTTPClient_CACert(t *testing.T) {
 	err := os.WriteFile(caCertPath, caCertPEM, 0o600)
 	require.NoError(t, err)
 
-	client, err := buildHTTPClient(false, caCertPath, "", "")
+	client, err := NewHTTPClient(false, caCertPath, "", "")
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	assert.NotEqual(t, http.DefaultClient, client)
@@ -107,16 +107,16 @@ func TestBuildHTTPClient_CACert(t *testing.T) {
 	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
 }
 
-func TestBuildHTTPClient_CACertNotFound(t *testing.T) {
+func TestNewHTTPClient_CACertNotFound(t *testing.T) {
 	t.Parallel()
 
-	client, err := buildHTTPClient(false, "/nonexistent/ca.crt", "", "")
+	client, err := NewHTTPClient(false, "/nonexistent/ca.crt", "", "")
 	assert.Error(t, err)
 	assert.Nil(t, client)
 	assert.Contains(t, err.Error(), "failed to read CA certificate")
 }
 
-func TestBuildHTTPClient_CACertInvalid(t *testing.T) {
+func TestNewHTTPClient_CACertInvalid(t *testing.T) {
 	t.Parallel()
 
 	// Create a temporary file with invalid content
@@ -125,31 +125,31 @@ func TestBuildHTTPClient_CACertInvalid(t *testing.T) {
 	err := os.WriteFile(caCertPath, []byte("not a valid certificate"), 0o600)
 	require.NoError(t, err)
 
-	client, err := buildHTTPClient(false, caCertPath, "", "")
+	client, err := NewHTTPClient(false, caCertPath, "", "")
 	assert.Error(t, err)
 	assert.Nil(t, client)
 	assert.Contains(t, err.Error(), "failed to parse CA certificate")
 }
 
-func TestBuildHTTPClient_CertWithoutKey(t *testing.T) {
+func TestNewHTTPClient_CertWithoutKey(t *testing.T) {
 	t.Parallel()
 
-	client, err := buildHTTPClient(false, "", "/path/to/cert.crt", "")
+	client, err := NewHTTPClient(false, "", "/path/to/cert.crt", "")
 	assert.Error(t, err)
 	assert.Nil(t, client)
 	assert.Contains(t, err.Error(), "both --cert and --cert-key must be provided together")
 }
 
-func TestBuildHTTPClient_KeyWithoutCert(t *testing.T) {
+func TestNewHTTPClient_KeyWithoutCert(t *testing.T) {
 	t.Parallel()
 
-	client, err := buildHTTPClient(false, "", "", "/path/to/key.pem")
+	client, err := NewHTTPClient(false, "", "", "/path/to/key.pem")
 	assert.Error(t, err)
 	assert.Nil(t, client)
 	assert.Contains(t, err.Error(), "both --cert and --cert-key must be provided together")
 }
 
-func TestBuildHTTPClient_CertAndKey(t *testing.T) {
+func TestNewHTTPClient_CertAndKey(t *testing.T) {
 	t.Parallel()
 
 	// Create temporary cert and key files
@@ -164,7 +164,7 @@ func TestBuildHTTPClient_CertAndKey(t *testing.T) {
 	err = os.WriteFile(keyPath, key, 0o600)
 	require.NoError(t, err)
 
-	client, err := buildHTTPClient(false, "", certPath, keyPath)
+	client, err := NewHTTPClient(false, "", certPath, keyPath)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	assert.NotEqual(t, http.DefaultClient, client)
@@ -176,16 +176,16 @@ func TestBuildHTTPClient_CertAndKey(t *testing.T) {
 	assert.Len(t, transport.TLSClientConfig.Certificates, 1)
 }
 
-func TestBuildHTTPClient_CertNotFound(t *testing.T) {
+func TestNewHTTPClient_CertNotFound(t *testing.T) {
 	t.Parallel()
 
-	client, err := buildHTTPClient(false, "", "/nonexistent/cert.crt", "/nonexistent/key.pem")
+	client, err := NewHTTPClient(false, "", "/nonexistent/cert.crt", "/nonexistent/key.pem")
 	assert.Error(t, err)
 	assert.Nil(t, client)
 	assert.Contains(t, err.Error(), "failed to load client certificate")
 }
 
-func TestBuildHTTPClient_InsecureWithCACert(t *testing.T) {
+func TestNewHTTPClient_InsecureWithCACert(t *testing.T) {
 	t.Parallel()
 
 	// Create a temporary CA cert file
@@ -198,7 +198,7 @@ func TestBuildHTTPClient_InsecureWithCACert(t *testing.T) {
 	require.NoError(t, err)
 
 	// Both insecure and CA cert can be set together
-	client, err := buildHTTPClient(true, caCertPath, "", "")
+	client, err := NewHTTPClient(true, caCertPath, "", "")
 	require.NoError(t, err)
 	require.NotNil(t, client)
 
@@ -445,6 +445,24 @@ func (r *Reader) readNode(ctx context.Context, node Node) (*ast.Taskfile, error)
 		if task.Location.Taskfile == "" {
 			task.Location.Taskfile = tf.Location
 		}
+		// and for each of its deps, commands and required variables
+		for _, dep := range task.Deps {
+			if dep != nil && dep.Location != nil {
+				dep.Location.Taskfile = tf.Location
+			}
+		}
+		for _, cmd := range task.Cmds {
+			if cmd != nil && cmd.Location != nil {
+				cmd.Location.Taskfile = tf.Location
+			}
+		}
+		if task.Requires != nil {
+			for _, v := range task.Requires.Vars {
+				if v != nil && v.Location != nil {
+					v.Location.Taskfile = tf.Location
+				}
+			}
+		}
 	}
 
 	return &tf, nil
@@ -0,0 +1,9 @@
+package ast
+
+import "github.com/go-task/task/v3/internal/jsonschema"
+
+// Schema returns the JSON Schema of .taskrc files, built from the types they
+// are decoded into.
+func Schema() *jsonschema.Schema {
+	return jsonschema.Document[TaskRC]("Taskrc YAML Schema", "Schema for .taskrc files.")
+}
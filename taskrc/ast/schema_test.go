@@ -0,0 +1,22 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/taskrc/ast"
+)
+
+func TestSchemaIsPublished(t *testing.T) {
+	t.Parallel()
+
+	published, err := os.ReadFile("../../website/src/public/schema-taskrc.json")
+	require.NoError(t, err)
+	generated, err := json.Marshal(ast.Schema())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(published), string(generated), "the published schema is outdated, run `task generate:schema`")
+}
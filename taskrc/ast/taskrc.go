@@ -10,27 +10,41 @@ import (
 )
 
 type TaskRC struct {
-	Version      *semver.Version `yaml:"version"`
-	Verbose      *bool           `yaml:"verbose"`
-	Color        *bool           `yaml:"color"`
-	DisableFuzzy *bool           `yaml:"disable-fuzzy"`
-	Concurrency  *int            `yaml:"concurrency"`
-	Interactive  *bool           `yaml:"interactive"`
-	Remote       Remote          `yaml:"remote"`
-	Failfast     bool            `yaml:"failfast"`
-	Experiments  map[string]int  `yaml:"experiments"`
+	Version      *semver.Version `yaml:"version" desc:"Version of the configuration format."`
+	Verbose      *bool           `yaml:"verbose" desc:"Enable verbose output."`
+	Color        *bool           `yaml:"color" desc:"Enable colored output."`
+	DisableFuzzy *bool           `yaml:"disable-fuzzy" desc:"Disable fuzzy matching for task names."`
+	Concurrency  *int            `yaml:"concurrency" desc:"Number of concurrent tasks to run." minimum:"1"`
+	Interactive  *bool           `yaml:"interactive" desc:"Prompt for missing required variables instead of failing. Requires a TTY." default:"false"`
+	Remote       Remote          `yaml:"remote" desc:"Remote configuration settings."`
+	Watch        Watch           `yaml:"watch" desc:"Watch mode settings."`
+	Failfast     bool            `yaml:"failfast" desc:"When running tasks in parallel, stop all tasks if one fails." default:"false"`
+	Experiments  map[string]int  `yaml:"experiments" desc:"Experiments to enable, by name. See https://taskfile.dev/experiments"`
 }
 
 type Remote struct {
-	Insecure     *bool          `yaml:"insecure"`
-	Offline      *bool          `yaml:"offline"`
-	Timeout      *time.Duration `yaml:"timeout"`
-	CacheExpiry  *time.Duration `yaml:"cache-expiry"`
-	CacheDir     *string        `yaml:"cache-dir"`
-	TrustedHosts []string       `yaml:"trusted-hosts"`
-	CACert       *string        `yaml:"cacert"`
-	Cert         *string        `yaml:"cert"`
-	CertKey      *string        `yaml:"cert-key"`
+	Insecure     *bool          `yaml:"insecure" desc:"Forces Task to download Taskfiles over insecure connections."`
+	Offline      *bool          `yaml:"offline" desc:"Forces Task to only use local or cached Taskfiles."`
+	Timeout      *time.Duration `yaml:"timeout" desc:"Timeout for downloading remote Taskfiles (e.g. 30s, 5m)."`
+	CacheExpiry  *time.Duration `yaml:"cache-expiry" desc:"Expiry duration for cached remote Taskfiles (e.g. 1h, 24h)."`
+	CacheDir     *string        `yaml:"cache-dir" desc:"Directory to cache remote Taskfiles."`
+	TrustedHosts []string       `yaml:"trusted-hosts" desc:"List of trusted hosts for remote Taskfiles (e.g. github.com, example.com:8080)."`
+	CACert       *string        `yaml:"cacert" desc:"Path to a CA certificate used to verify the servers of remote Taskfiles."`
+	Cert         *string        `yaml:"cert" desc:"Path to a client certificate used to download remote Taskfiles."`
+	CertKey      *string        `yaml:"cert-key" desc:"Path to the key of the client certificate."`
+	// BuildCacheURL is the URL of a server that shares the build cache
+	// between machines
+	BuildCacheURL      *string `yaml:"build-cache-url" desc:"URL of a server that shares the build cache between machines."`
+	BuildCacheReadOnly *bool   `yaml:"build-cache-read-only" desc:"Only reads from the shared build cache, without uploading to it."`
+}
+
+type Watch struct {
+	// Poll makes the watch mode poll the file system instead of relying on
+	// its events
+	Poll *bool `yaml:"poll" desc:"Poll the file system for changes instead of relying on its events, e.g. on network file systems." default:"false"`
+	// Ignore are patterns of directories that are not watched, in the syntax
+	// of .gitignore files
+	Ignore []string `yaml:"ignore" desc:"Patterns of directories that are not watched, in the syntax of .gitignore files and relative to the directory of the Taskfile."`
 }
 
 // Merge combines the current TaskRC with another TaskRC, prioritizing non-nil fields from the other TaskRC.
@@ -61,6 +75,13 @@ func (t *TaskRC) Merge(other *TaskRC) {
 	t.Remote.CACert = cmp.Or(other.Remote.CACert, t.Remote.CACert)
 	t.Remote.Cert = cmp.Or(other.Remote.Cert, t.Remote.Cert)
 	t.Remote.CertKey = cmp.Or(other.Remote.CertKey, t.Remote.CertKey)
+	t.Remote.BuildCacheURL = cmp.Or(other.Remote.BuildCacheURL, t.Remote.BuildCacheURL)
+	t.Remote.BuildCacheReadOnly = cmp.Or(other.Remote.BuildCacheReadOnly, t.Remote.BuildCacheReadOnly)
+
+	t.Watch.Poll = cmp.Or(other.Watch.Poll, t.Watch.Poll)
+	if len(other.Watch.Ignore) > 0 {
+		t.Watch.Ignore = slices.Concat(t.Watch.Ignore, other.Watch.Ignore)
+	}
 
 	t.Verbose = cmp.Or(other.Verbose, t.Verbose)
 	t.Color = cmp.Or(other.Color, t.Color)
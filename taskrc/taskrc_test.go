@@ -169,6 +169,64 @@ remote:
 	assert.Equal(t, []string{"github.com", "gitlab.com", "example.com:8080"}, cfg.Remote.TrustedHosts)
 }
 
+func TestGetConfig_RemoteBuildCache(t *testing.T) { //nolint:paralleltest // cannot run in parallel
+	xdgDir, _, localDir := setupDirs(t)
+
+	writeFile(t, xdgDir, "taskrc.yml", `
+remote:
+  build-cache-url: https://cache.example.com
+  cacert: /path/to/ca.crt
+`)
+	writeFile(t, localDir, ".taskrc.yml", `
+remote:
+  build-cache-read-only: true
+`)
+
+	cfg, err := GetConfig(localDir)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	require.NotNil(t, cfg.Remote.BuildCacheURL)
+	assert.Equal(t, "https://cache.example.com", *cfg.Remote.BuildCacheURL)
+	require.NotNil(t, cfg.Remote.BuildCacheReadOnly)
+	assert.True(t, *cfg.Remote.BuildCacheReadOnly)
+	require.NotNil(t, cfg.Remote.CACert)
+	assert.Equal(t, "/path/to/ca.crt", *cfg.Remote.CACert)
+}
+
+func TestGetConfig_Watch(t *testing.T) { //nolint:paralleltest // cannot run in parallel
+	xdgDir, _, localDir := setupDirs(t)
+
+	writeFile(t, xdgDir, "taskrc.yml", `
+watch:
+  poll: true
+  ignore:
+    - "*.log"
+`)
+	writeFile(t, localDir, ".taskrc.yml", `
+watch:
+  ignore:
+    - dist/
+`)
+
+	cfg, err := GetConfig(localDir)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	require.NotNil(t, cfg.Watch.Poll)
+	assert.True(t, *cfg.Watch.Poll)
+	// The patterns of the closest config come last, so they take precedence
+	assert.Equal(t, []string{"*.log", "dist/"}, cfg.Watch.Ignore)
+
+	writeFile(t, localDir, ".taskrc.yml", `
+watch:
+  poll: false
+`)
+
+	cfg, err = GetConfig(localDir)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Watch.Poll)
+	assert.False(t, *cfg.Watch.Poll)
+}
+
 func TestGetConfig_RemoteTrustedHostsMerge(t *testing.T) { //nolint:paralleltest // cannot run in parallel
 	t.Run("file-based merge precedence", func(t *testing.T) { //nolint:paralleltest // parent test cannot run in parallel
 		xdgConfigDir, homeDir, localDir := setupDirs(t)
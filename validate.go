@@ -0,0 +1,372 @@
+package task
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sajari/fuzzy"
+
+	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/internal/lint"
+	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/internal/templater"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Validate checks the Taskfile and the Taskfiles it includes for problems
+// without running any task. The findings are sorted by location.
+func (e *Executor) Validate() []lint.Finding {
+	v := &validator{
+		e:       e,
+		callers: map[string][]taskCaller{},
+	}
+	v.checkReferences()
+	for t := range e.Taskfile.Tasks.Values(sort.AlphaNumeric) {
+		v.checkTemplates(t)
+		v.checkEnums(t)
+		v.checkPlatforms(t)
+		if t.Internal && len(v.callers[t.Task]) == 0 {
+			v.report(lint.SeverityWarning, lint.RuleUnusedInternal, t, t.Location,
+				"internal task %q is never called by another task", t.Task)
+		}
+	}
+	v.checkAliases()
+	v.checkCycles()
+	lint.Sort(v.findings)
+	return v.findings
+}
+
+type validator struct {
+	e        *Executor
+	findings []lint.Finding
+	// callers holds the deps and cmds that call each task
+	callers map[string][]taskCaller
+}
+
+type taskCaller struct {
+	task     *ast.Task
+	vars     *ast.Vars
+	location *ast.Location
+}
+
+func (v *validator) report(severity lint.Severity, rule string, t *ast.Task, loc *ast.Location, format string, args ...any) {
+	f := lint.Finding{
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if t != nil {
+		f.Task = t.Task
+		if loc == nil {
+			loc = t.Location
+		}
+	}
+	if loc != nil {
+		f.Location = &lint.Location{
+			Taskfile: loc.Taskfile,
+			Line:     loc.Line,
+			Column:   loc.Column,
+		}
+	}
+	v.findings = append(v.findings, f)
+}
+
+// checkReferences reports the deps and cmds that call tasks that don't exist
+// and records the callers of the ones that do.
+func (v *validator) checkReferences() {
+	var model *fuzzy.Model
+	for t := range v.e.Taskfile.Tasks.Values(sort.AlphaNumeric) {
+		check := func(name string, vars *ast.Vars, loc *ast.Location) {
+			// Names that depend on variables can only be resolved at runtime
+			if name == "" || strings.Contains(name, "{{") {
+				return
+			}
+			matchingTasks, err := v.e.FindMatchingTasks(&Call{Task: name})
+			if err != nil {
+				// Conflicting aliases are reported by checkAliases
+				return
+			}
+			if len(matchingTasks) > 0 {
+				callee := matchingTasks[0].Task
+				v.callers[callee.Task] = append(v.callers[callee.Task], taskCaller{task: t, vars: vars, location: loc})
+				return
+			}
+			if model == nil {
+				model = v.taskNamesModel()
+			}
+			message := fmt.Sprintf("task %q does not exist", name)
+			if didYouMean := model.SpellCheck(name); didYouMean != "" {
+				message += fmt.Sprintf(". Did you mean %q?", didYouMean)
+			}
+			v.report(lint.SeverityError, lint.RuleMissingTask, t, loc, "%s", message)
+		}
+		for _, dep := range t.Deps {
+			if dep != nil {
+				check(dep.Task, dep.Vars, dep.Location)
+			}
+		}
+		for _, cmd := range t.Cmds {
+			if cmd != nil {
+				check(cmd.Task, cmd.Vars, cmd.Location)
+			}
+		}
+	}
+}
+
+// taskNamesModel returns a model to suggest task names for misspelled
+// references. Unlike the one used for calls from the command line, it also
+// knows about internal tasks, as other tasks can call them.
+func (v *validator) taskNamesModel() *fuzzy.Model {
+	model := fuzzy.NewModel()
+	model.SetThreshold(1)
+	var words []string
+	for name, t := range v.e.Taskfile.Tasks.All(nil) {
+		words = append(words, name)
+		words = append(words, t.Aliases...)
+	}
+	model.Train(words)
+	return model
+}
+
+// checkTemplates reports the templates of the task that are invalid or that
+// use variables that are never defined.
+func (v *validator) checkTemplates(t *ast.Task) {
+	known := v.knownVars(t)
+	reported := map[string]bool{}
+	check := func(s string, loc *ast.Location) {
+		names, err := templater.ReferencedVars(s)
+		if err != nil {
+			v.report(lint.SeverityError, lint.RuleInvalidTemplate, t, loc, "invalid template: %v", err)
+			return
+		}
+		for _, name := range names {
+			if known[name] || reported[name] {
+				continue
+			}
+			reported[name] = true
+			v.report(lint.SeverityWarning, lint.RuleUnknownVar, t, loc, "variable %q is not defined", name)
+		}
+	}
+	checkVars := func(vars *ast.Vars, loc *ast.Location) {
+		for value := range vars.Values() {
+			if s, ok := value.Value.(string); ok {
+				check(s, loc)
+			}
+		}
+	}
+
+	for _, s := range []string{t.Label, t.Prefix, t.Dir, t.If, t.Fingerprint} {
+		check(s, nil)
+	}
+	for _, s := range t.Status {
+		check(s, nil)
+	}
+	for _, p := range t.Preconditions {
+		check(p.Sh, nil)
+		check(p.Msg, nil)
+	}
+	for _, g := range slices.Concat(t.Sources, t.Generates) {
+		check(g.Glob, nil)
+	}
+	for _, dep := range t.Deps {
+		if dep == nil {
+			continue
+		}
+		check(dep.Task, dep.Location)
+		checkVars(dep.Vars, dep.Location)
+	}
+	for _, cmd := range t.Cmds {
+		if cmd == nil {
+			continue
+		}
+		check(cmd.Cmd, cmd.Location)
+		check(cmd.Task, cmd.Location)
+		check(cmd.If, cmd.Location)
+		checkVars(cmd.Vars, cmd.Location)
+	}
+}
+
+// knownVars returns the names of the variables that may be defined when the
+// given task runs.
+func (v *validator) knownVars(t *ast.Task) map[string]bool {
+	known := map[string]bool{}
+	for _, name := range templater.SpecialVars() {
+		known[name] = true
+	}
+	for _, vars := range []*ast.Vars{
+		env.GetEnviron(),
+		v.e.Taskfile.Env,
+		v.e.Taskfile.Vars,
+		t.IncludeVars,
+		t.IncludedTaskfileVars,
+		t.Vars,
+	} {
+		for name := range vars.Keys() {
+			known[name] = true
+		}
+	}
+	if t.Requires != nil {
+		for _, required := range t.Requires.Vars {
+			known[required.Name] = true
+		}
+	}
+	for _, caller := range v.callers[t.Task] {
+		for name := range caller.vars.Keys() {
+			known[name] = true
+		}
+	}
+	for _, cmd := range t.Cmds {
+		if cmd != nil && cmd.For != nil && cmd.For.As != "" {
+			known[cmd.For.As] = true
+		}
+	}
+	for _, dep := range t.Deps {
+		if dep != nil && dep.For != nil && dep.For.As != "" {
+			known[dep.For.As] = true
+		}
+	}
+	return known
+}
+
+// checkEnums reports the callers of the task that give one of its required
+// variables a value that is not allowed, and the required variables that none
+// of the callers of the task sets to an allowed value.
+func (v *validator) checkEnums(t *ast.Task) {
+	if t.Requires == nil {
+		return
+	}
+	callers := v.callers[t.Task]
+	for _, required := range t.Requires.Vars {
+		if len(required.Enum) == 0 || len(callers) == 0 {
+			continue
+		}
+		satisfied := false
+		for _, caller := range callers {
+			value, fromCaller := v.callerValue(t, caller, required)
+			s, isString := value.(string)
+			// Values that depend on variables can only be checked at runtime
+			if value != nil && (!isString || strings.Contains(s, "{{") || slices.Contains(required.Enum, s)) {
+				satisfied = true
+				continue
+			}
+			if fromCaller {
+				v.report(lint.SeverityError, lint.RuleEnumValue, caller.task, caller.location,
+					"task %q is called with %s=%q, which is not one of the allowed values %v",
+					t.Task, required.Name, s, required.Enum)
+			}
+		}
+		if !satisfied {
+			v.report(lint.SeverityWarning, lint.RuleEnumValue, t, required.Location,
+				"no caller of task %q sets %s to one of the allowed values %v",
+				t.Task, required.Name, required.Enum)
+		}
+	}
+}
+
+// callerValue returns the value that the required variable has when the task
+// is called by the given caller, if it is known before running anything, and
+// whether the caller sets it.
+func (v *validator) callerValue(t *ast.Task, caller taskCaller, required *ast.VarsWithValidation) (any, bool) {
+	if value, ok := caller.vars.Get(required.Name); ok {
+		return varValue(value), true
+	}
+	for _, vars := range []*ast.Vars{t.IncludeVars, t.IncludedTaskfileVars, v.e.Taskfile.Vars} {
+		if value, ok := vars.Get(required.Name); ok {
+			return varValue(value), false
+		}
+	}
+	if required.Default != nil {
+		return *required.Default, false
+	}
+	return nil, false
+}
+
+// varValue returns the value of the variable. Variables that are set by a
+// command or refer to another one are returned as is, as their value is only
+// known at runtime.
+func varValue(value ast.Var) any {
+	if value.Sh != nil || value.Ref != "" {
+		return value
+	}
+	return value.Value
+}
+
+// checkPlatforms reports the cmds of the task that never run, because none of
+// their platforms is one of the platforms of the task.
+func (v *validator) checkPlatforms(t *ast.Task) {
+	if len(t.Platforms) == 0 {
+		return
+	}
+	for _, cmd := range t.Cmds {
+		if cmd == nil || len(cmd.Platforms) == 0 {
+			continue
+		}
+		matches := slices.ContainsFunc(cmd.Platforms, func(p *ast.Platform) bool {
+			return slices.ContainsFunc(t.Platforms, func(tp *ast.Platform) bool {
+				return platformsOverlap(p, tp)
+			})
+		})
+		if !matches {
+			v.report(lint.SeverityWarning, lint.RulePlatforms, t, cmd.Location,
+				"command never runs, as its platforms don't match the ones of task %q", t.Task)
+		}
+	}
+}
+
+func platformsOverlap(a, b *ast.Platform) bool {
+	return (a.OS == "" || b.OS == "" || a.OS == b.OS) &&
+		(a.Arch == "" || b.Arch == "" || a.Arch == b.Arch)
+}
+
+// checkAliases reports aliases that can never be called, because another task
+// has the same name, and aliases that are used by more than one task.
+func (v *validator) checkAliases() {
+	owners := map[string][]*ast.Task{}
+	for t := range v.e.Taskfile.Tasks.Values(sort.AlphaNumeric) {
+		for _, alias := range t.Aliases {
+			if other, ok := v.e.Taskfile.Tasks.Get(alias); ok && other != t {
+				v.report(lint.SeverityWarning, lint.RuleShadowedAlias, t, nil,
+					"alias %q of task %q is shadowed by the task of the same name", alias, t.Task)
+				continue
+			}
+			if !slices.Contains(owners[alias], t) {
+				owners[alias] = append(owners[alias], t)
+			}
+		}
+	}
+	for _, alias := range slices.Sorted(maps.Keys(owners)) {
+		tasks := owners[alias]
+		if len(tasks) < 2 {
+			continue
+		}
+		names := make([]string, len(tasks))
+		for i, t := range tasks {
+			names[i] = fmt.Sprintf("%q", t.Task)
+		}
+		v.report(lint.SeverityError, lint.RuleDuplicateAlias, tasks[1], nil,
+			"alias %q is used by tasks %s", alias, strings.Join(names, ", "))
+	}
+}
+
+// checkCycles reports tasks that always call each other in a cycle. They only
+// make a run fail when one of them is called, so the rest of the Taskfile can
+// still be used.
+func (v *validator) checkCycles() {
+	g, cycle, err := v.e.taskCycle(slices.Collect(v.e.Taskfile.Tasks.Values(sort.AlphaNumeric)))
+	if err != nil || cycle == nil {
+		return
+	}
+	t, _ := v.e.Taskfile.Tasks.Get(cycle[0])
+	var loc *ast.Location
+	if ref := g.Reference(cycle[0], cycle[1]); ref != nil {
+		loc = ref.Location
+	}
+	names := make([]string, len(cycle))
+	for i, name := range cycle {
+		names[i] = fmt.Sprintf("%q", name)
+	}
+	v.report(lint.SeverityError, lint.RuleTaskCycle, t, loc,
+		"tasks call each other in a cycle: %s", strings.Join(names, " -> "))
+}
@@ -1,10 +1,12 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -62,6 +64,8 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 		Interactive:          origTask.Interactive,
 		Internal:             origTask.Internal,
 		Method:               origTask.Method,
+		Fingerprint:          origTask.Fingerprint,
+		Track:                origTask.Track,
 		Prefix:               origTask.Prefix,
 		IgnoreError:          origTask.IgnoreError,
 		Run:                  origTask.Run,
@@ -71,8 +75,11 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 		Location:             origTask.Location,
 		Requires:             origTask.Requires,
 		Watch:                origTask.Watch,
+		WatchOptions:         origTask.WatchOptions,
 		Namespace:            origTask.Namespace,
 		Failfast:             origTask.Failfast,
+		Timeout:              origTask.Timeout,
+		Retry:                origTask.Retry,
 	}, nil
 }
 
@@ -118,6 +125,8 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		Interactive:          origTask.Interactive,
 		Internal:             origTask.Internal,
 		Method:               templater.Replace(origTask.Method, cache),
+		Fingerprint:          templater.Replace(origTask.Fingerprint, cache),
+		Track:                origTask.Track,
 		Prefix:               templater.Replace(origTask.Prefix, cache),
 		IgnoreError:          origTask.IgnoreError,
 		Run:                  templater.Replace(origTask.Run, cache),
@@ -128,7 +137,11 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		Location:             origTask.Location,
 		Requires:             origTask.Requires,
 		Watch:                origTask.Watch,
+		WatchOptions:         origTask.WatchOptions,
 		Failfast:             origTask.Failfast,
+		Timeout:              origTask.Timeout,
+		Retry:                origTask.Retry,
+		Cache:                origTask.Cache,
 		Namespace:            origTask.Namespace,
 		FullName:             fullName,
 	}
@@ -181,6 +194,14 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		}
 	}
 
+	if origTask.Track != nil && origTask.Track.All {
+		new.Track = &ast.Track{
+			All:  true,
+			Vars: e.declaredVarNames(&new, call),
+			Env:  slices.Sorted(new.Env.Keys()),
+		}
+	}
+
 	if len(origTask.Sources) > 0 && origTask.Method != "none" {
 		var checker fingerprint.SourcesCheckable
 
@@ -190,7 +211,7 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 			checker = fingerprint.NewChecksumChecker(e.TempDir.Fingerprint, e.Dry)
 		}
 
-		value, err := checker.Value(&new)
+		value, err := checker.Value(context.Background(), &new)
 		if err != nil {
 			return nil, err
 		}
@@ -464,3 +485,24 @@ func product(matrix *ast.Matrix) []map[string]any {
 
 	return result
 }
+
+// declaredVarNames returns the sorted names of the variables of the compiled
+// task that are declared in the Taskfiles or given by the call. Variables that
+// come from the environment and the special ones are left out, as they differ
+// between machines and runs.
+func (e *Executor) declaredVarNames(t *ast.Task, call *Call) []string {
+	declared := []*ast.Vars{e.Taskfile.Vars, t.IncludeVars, t.IncludedTaskfileVars, call.Vars}
+	if origTask, ok := e.Taskfile.Tasks.Get(t.Task); ok {
+		declared = append(declared, origTask.Vars)
+	}
+	var names []string
+	for _, d := range declared {
+		for name := range d.Keys() {
+			if !templater.IsSpecialVar(name) && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
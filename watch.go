@@ -17,9 +17,9 @@ import (
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/fingerprint"
-	"github.com/go-task/task/v3/internal/fsnotifyext"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/slicesext"
+	"github.com/go-task/task/v3/internal/watcher"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -34,18 +34,6 @@ func (e *Executor) watchTasks(calls ...*Call) error {
 
 	e.Logger.Errf(logger.Green, "task: Started watching for tasks: %s\n", strings.Join(tasks, ", "))
 
-	ctx, cancel := context.WithCancel(context.Background())
-	for _, c := range calls {
-		go func() {
-			err := e.RunTask(ctx, c)
-			if err == nil {
-				e.Logger.Errf(logger.Green, "task: task \"%s\" finished running\n", c.Task)
-			} else if !isContextError(err) {
-				e.Logger.Errf(logger.Red, "%v\n", err)
-			}
-		}()
-	}
-
 	var waitTime time.Duration
 	switch {
 	case e.Interval != 0:
@@ -56,68 +44,62 @@ func (e *Executor) watchTasks(calls ...*Call) error {
 		waitTime = defaultWaitTime
 	}
 
-	w, err := fsnotify.NewWatcher()
+	runners := make([]*watchRunner, len(calls))
+	for i, c := range calls {
+		t, err := e.GetTask(c)
+		if err != nil {
+			return err
+		}
+		runners[i] = &watchRunner{
+			e:        e,
+			call:     c,
+			strategy: ast.WatchRestart,
+			debounce: waitTime,
+			events:   make(chan fsnotify.Event, 100),
+		}
+		if t.WatchOptions != nil {
+			if t.WatchOptions.Strategy != "" {
+				runners[i].strategy = t.WatchOptions.Strategy
+			}
+			if t.WatchOptions.Debounce != 0 {
+				runners[i].debounce = t.WatchOptions.Debounce
+			}
+		}
+		go runners[i].run()
+	}
+
+	w, err := e.newWatcher(waitTime)
 	if err != nil {
-		cancel()
 		return err
 	}
 	defer w.Close()
 
-	deduper := fsnotifyext.NewDeduper(w, waitTime)
-	eventsChan := deduper.GetChan()
-
 	closeOnInterrupt(w)
 
 	go func() {
 		for {
 			select {
-			case event, ok := <-eventsChan:
+			case event, ok := <-w.Events():
 				if !ok {
-					cancel()
+					for _, r := range runners {
+						close(r.events)
+					}
 					return
 				}
+				if event.Has(fsnotify.Chmod) {
+					continue
+				}
 				e.Logger.VerboseErrf(logger.Magenta, "task: received watch event: %v\n", event)
-
-				cancel()
-				ctx, cancel = context.WithCancel(context.Background())
-
+				// The dynamic variables may depend on the changed file. The
+				// cache is reset here once, rather than by every runner while
+				// the others compile their tasks.
 				e.Compiler.ResetCache()
-
-				for _, c := range calls {
-					go func() {
-						if ShouldIgnore(event.Name) {
-							e.Logger.VerboseErrf(logger.Magenta, "task: event skipped for being an ignored dir: %s\n", event.Name)
-							return
-						}
-						t, err := e.GetTask(c)
-						if err != nil {
-							e.Logger.Errf(logger.Red, "%v\n", err)
-							return
-						}
-						baseDir := filepathext.SmartJoin(e.Dir, t.Dir)
-						files, err := e.collectSources(calls)
-						if err != nil {
-							e.Logger.Errf(logger.Red, "%v\n", err)
-							return
-						}
-
-						if !event.Has(fsnotify.Remove) && !slices.Contains(files, event.Name) {
-							relPath, _ := filepath.Rel(baseDir, event.Name)
-							e.Logger.VerboseErrf(logger.Magenta, "task: skipped for file not in sources: %s\n", relPath)
-							return
-						}
-						err = e.RunTask(ctx, c)
-						if err == nil {
-							e.Logger.Errf(logger.Green, "task: task \"%s\" finished running\n", c.Task)
-						} else if !isContextError(err) {
-							e.Logger.Errf(logger.Red, "%v\n", err)
-						}
-					}()
+				for _, r := range runners {
+					r.events <- event
 				}
-			case err, ok := <-w.Errors:
+			case err, ok := <-w.Errors():
 				switch {
 				case !ok:
-					cancel()
 					return
 				default:
 					e.Logger.Errf(logger.Red, "%v\n", err)
@@ -127,6 +109,7 @@ func (e *Executor) watchTasks(calls ...*Call) error {
 	}()
 
 	e.watchedDirs = xsync.NewMap[string, bool]()
+	e.watchIgnorer = watcher.NewIgnorer(e.Dir, e.WatchIgnore)
 
 	go func() {
 		// NOTE(@andreynering): New files can be created in directories
@@ -144,6 +127,142 @@ func (e *Executor) watchTasks(calls ...*Call) error {
 	return nil
 }
 
+// watchRunner runs a watched task again when its sources change. Events are
+// batched until none is received for the debounce window, and the strategy
+// tells what to do with a batch that arrives while the task is still running.
+type watchRunner struct {
+	e        *Executor
+	call     *Call
+	strategy string
+	debounce time.Duration
+	events   chan fsnotify.Event
+}
+
+func (r *watchRunner) run() {
+	var (
+		pending  []fsnotify.Event
+		debounce <-chan time.Time
+		running  bool
+		cancel   context.CancelFunc
+		// done is buffered, so that a run that finishes after the runner
+		// stopped doesn't block forever
+		done = make(chan struct{}, 1)
+		// queued holds the files changed since the start of the current run
+		// when the task must run again once it finishes
+		queued []string
+		rerun  bool
+	)
+
+	start := func(files []string) {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		running = true
+		go func() {
+			r.runTask(ctx, files)
+			done <- struct{}{}
+		}()
+	}
+
+	start(nil)
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				cancel()
+				return
+			}
+			pending = append(pending, event)
+			debounce = time.After(r.debounce)
+
+		case <-debounce:
+			files := r.changedFiles(pending)
+			pending, debounce = nil, nil
+			switch {
+			case len(files) == 0:
+			case !running:
+				start(files)
+			case r.strategy == ast.WatchIgnoreWhileRunning:
+				r.e.Logger.VerboseErrf(logger.Magenta, "task: changes ignored while task \"%s\" is running\n", r.call.Task)
+			case r.strategy == ast.WatchQueue:
+				r.e.Logger.VerboseErrf(logger.Magenta, "task: task \"%s\" will run again when it finishes\n", r.call.Task)
+				queued = append(queued, files...)
+				rerun = true
+			default:
+				r.e.Logger.VerboseErrf(logger.Magenta, "task: restarting task \"%s\"\n", r.call.Task)
+				queued = append(queued, files...)
+				rerun = true
+				// The task runs again once the canceled run exits, so that
+				// two runs never overlap
+				cancel()
+			}
+
+		case <-done:
+			running = false
+			cancel()
+			if rerun {
+				start(slicesext.UniqueJoin(queued))
+				queued, rerun = nil, false
+			}
+		}
+	}
+}
+
+func (r *watchRunner) runTask(ctx context.Context, files []string) {
+	vars := r.call.Vars.DeepCopy()
+	if vars == nil {
+		vars = ast.NewVars()
+	}
+	vars.Set("WATCH_CHANGED_FILES", ast.Var{Value: files})
+	call := &Call{
+		Task:     r.call.Task,
+		Vars:     vars,
+		Silent:   r.call.Silent,
+		Indirect: r.call.Indirect,
+	}
+
+	err := r.e.RunTask(ctx, call)
+	if err == nil {
+		r.e.Logger.Errf(logger.Green, "task: task \"%s\" finished running\n", r.call.Task)
+	} else if !isContextError(err) {
+		r.e.Logger.Errf(logger.Red, "%v\n", err)
+	}
+}
+
+// changedFiles returns the files of the given events that are sources of the
+// task, relative to its directory. Removed files are always included, as they
+// can't be matched against the sources anymore.
+func (r *watchRunner) changedFiles(events []fsnotify.Event) []string {
+	t, err := r.e.GetTask(r.call)
+	if err != nil {
+		r.e.Logger.Errf(logger.Red, "%v\n", err)
+		return nil
+	}
+	baseDir := filepathext.SmartJoin(r.e.Dir, t.Dir)
+	sources, err := r.e.collectSources([]*Call{r.call})
+	if err != nil {
+		r.e.Logger.Errf(logger.Red, "%v\n", err)
+		return nil
+	}
+
+	var files []string
+	for _, event := range events {
+		if ShouldIgnore(event.Name) || r.e.isIgnoredDir(filepath.Dir(event.Name)) {
+			r.e.Logger.VerboseErrf(logger.Magenta, "task: event skipped for being an ignored path: %s\n", event.Name)
+			continue
+		}
+		relPath, err := filepath.Rel(baseDir, event.Name)
+		if err != nil {
+			relPath = event.Name
+		}
+		if !event.Has(fsnotify.Remove) && !slices.Contains(sources, event.Name) {
+			r.e.Logger.VerboseErrf(logger.Magenta, "task: skipped for file not in sources: %s\n", relPath)
+			continue
+		}
+		files = append(files, relPath)
+	}
+	return slicesext.UniqueJoin(files)
+}
+
 func isContextError(err error) bool {
 	if taskRunErr, ok := err.(*errors.TaskRunError); ok {
 		err = taskRunErr.Err
@@ -152,7 +271,17 @@ func isContextError(err error) bool {
 	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
-func closeOnInterrupt(w *fsnotify.Watcher) {
+// newWatcher returns the watcher of the file system. The polling watcher lists
+// the watched directories every interval.
+func (e *Executor) newWatcher(interval time.Duration) (watcher.Watcher, error) {
+	if e.WatchPoll {
+		e.Logger.VerboseErrf(logger.Magenta, "task: polling for changes every %v\n", interval)
+		return watcher.NewPoll(interval), nil
+	}
+	return watcher.NewFSNotify()
+}
+
+func closeOnInterrupt(w watcher.Watcher) {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -162,29 +291,45 @@ func closeOnInterrupt(w *fsnotify.Watcher) {
 	}()
 }
 
-func (e *Executor) registerWatchedDirs(w *fsnotify.Watcher, calls ...*Call) error {
+func (e *Executor) registerWatchedDirs(w watcher.Watcher, calls ...*Call) error {
 	files, err := e.collectSources(calls)
 	if err != nil {
 		return err
 	}
+	var added int
 	for _, f := range files {
 		d := filepath.Dir(f)
 		if isSet, ok := e.watchedDirs.Load(d); ok && isSet {
 			continue
 		}
-		if ShouldIgnore(d) {
+		if e.isIgnoredDir(d) {
 			continue
 		}
 		if err := w.Add(d); err != nil {
 			return err
 		}
 		e.watchedDirs.Store(d, true)
+		added++
 		relPath, _ := filepath.Rel(e.Dir, d)
 		e.Logger.VerboseOutf(logger.Green, "task: watching new dir: %v\n", relPath)
 	}
+	if added > 0 {
+		e.Logger.VerboseOutf(logger.Green, "task: watching %d directories\n", e.watchedDirs.Size())
+	}
 	return nil
 }
 
+// isIgnoredDir returns true if the given directory must not be watched,
+// because it is one of the directories that are always ignored or it matches
+// the ignore files and patterns. Files are not checked against the patterns,
+// as the sources of a task are watched even if they are ignored by git.
+func (e *Executor) isIgnoredDir(dir string) bool {
+	if ShouldIgnore(dir) {
+		return true
+	}
+	return e.watchIgnorer != nil && e.watchIgnorer.Ignored(dir, true)
+}
+
 var ignorePaths = []string{
 	"/.task",
 	"/.git",
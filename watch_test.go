@@ -81,6 +81,125 @@ task: task "default" finished running
 	assert.Equal(t, expectedOutput, strings.TrimSpace(buff.String()))
 }
 
+func TestFileWatchPoll(t *testing.T) {
+	t.Parallel()
+
+	const dir = "testdata/watch_poll"
+	_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+	_ = os.RemoveAll(filepathext.SmartJoin(dir, "src"))
+
+	expectedOutput := strings.TrimSpace(`
+task: Started watching for tasks: default
+task: [default] echo "Task running!"
+Task running!
+task: task "default" finished running
+task: [default] echo "Task running!"
+Task running!
+task: task "default" finished running
+	`)
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithWatch(true),
+		task.WithWatchPoll(true),
+		task.WithInterval(50*time.Millisecond),
+	)
+
+	require.NoError(t, e.Setup())
+	buff.Reset()
+
+	dirPath := filepathext.SmartJoin(dir, "src")
+	filePath := filepathext.SmartJoin(dirPath, "a")
+	require.NoError(t, os.MkdirAll(dirPath, 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte("test"), 0o644))
+
+	go func() {
+		_ = e.Run(context.Background(), &task.Call{Task: "default"})
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filePath, []byte("test updated"), 0o644))
+
+	time.Sleep(400 * time.Millisecond)
+	assert.Equal(t, expectedOutput, strings.TrimSpace(buff.String()))
+}
+
+func TestFileWatchChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	const dir = "testdata/watch_changed_files"
+	_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+	_ = os.RemoveAll(filepathext.SmartJoin(dir, "src"))
+
+	expectedOutput := strings.TrimSpace(`
+task: Started watching for tasks: default
+task: [default] echo "Changed files: []"
+Changed files: []
+task: task "default" finished running
+task: [default] echo "Changed files: [src/a src/b]"
+Changed files: [src/a src/b]
+task: task "default" finished running
+	`)
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+	)
+
+	require.NoError(t, e.Setup())
+	buff.Reset()
+
+	dirPath := filepathext.SmartJoin(dir, "src")
+	require.NoError(t, os.MkdirAll(dirPath, 0o755))
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(dirPath, "a"), []byte("test"), 0o644))
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(dirPath, "b"), []byte("test"), 0o644))
+
+	go func() {
+		_ = e.Run(context.Background(), &task.Call{Task: "default"})
+	}()
+
+	// Both changes happen within the debounce window of the task, so it runs
+	// only once more
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(dirPath, "a"), []byte("test updated"), 0o644))
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(dirPath, "b"), []byte("test updated"), 0o644))
+
+	time.Sleep(400 * time.Millisecond)
+	assert.Equal(t, expectedOutput, strings.TrimSpace(buff.String()))
+}
+
+func TestFileWatchIgnore(t *testing.T) {
+	t.Parallel()
+
+	const dir = "testdata/watch_ignore"
+	_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithWatch(true),
+		task.WithVerbose(true),
+		task.WithWatchIgnore([]string{"/other"}),
+	)
+	require.NoError(t, e.Setup())
+
+	go func() {
+		_ = e.Run(context.Background(), &task.Call{Task: "default"})
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Contains(t, buff.String(), "task: watching new dir: src\n")
+	assert.Contains(t, buff.String(), "task: watching 1 directories\n")
+	assert.NotContains(t, buff.String(), "generated")
+}
+
 func TestShouldIgnore(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Why prints why each of the given tasks is or isn't up-to-date, without
+// running them
+func (e *Executor) Why(ctx context.Context, calls ...*Call) error {
+	for _, call := range calls {
+		origTask, err := e.GetTask(call)
+		if err != nil {
+			return err
+		}
+		t, err := e.CompiledTask(call)
+		if err != nil {
+			return err
+		}
+
+		method := e.Taskfile.Method
+		if t.Method != "" {
+			method = t.Method
+		}
+		explanation, err := fingerprint.ExplainTask(ctx, t,
+			fingerprint.WithMethod(method),
+			fingerprint.WithDefinition(origTask),
+			fingerprint.WithTempDir(e.TempDir.Fingerprint),
+			fingerprint.WithLogger(e.Logger),
+		)
+		if err != nil {
+			return err
+		}
+		e.printExplanation(t, explanation)
+	}
+	return nil
+}
+
+func (e *Executor) printExplanation(t *ast.Task, explanation *fingerprint.Explanation) {
+	if explanation.UpToDate {
+		e.Logger.Outf(logger.Green, "task: Task %q is up to date\n", t.Name())
+	} else {
+		e.Logger.Outf(logger.Yellow, "task: Task %q is not up to date\n", t.Name())
+	}
+
+	if explanation.Sources == nil && len(explanation.Status) == 0 {
+		e.Logger.Outf(logger.Default, "  it has no sources or status, so it always runs\n")
+		return
+	}
+
+	if s := explanation.Sources; s != nil {
+		e.Logger.Outf(logger.Default, "  sources (method %s):\n", explanation.Method)
+		if s.DefinitionChanged {
+			e.Logger.Outf(logger.Yellow, "    the definition of the task changed since the last run\n")
+		}
+		switch {
+		case s.NeverRan:
+			e.Logger.Outf(logger.Yellow, "    no fingerprint of a previous run\n")
+		case len(s.Changed) > 0:
+			for _, c := range s.Changed {
+				e.Logger.Outf(logger.Yellow, "    %s: %s\n", c.Change, c.Path)
+			}
+		case s.Reason != "":
+			e.Logger.Outf(logger.Yellow, "    %s\n", s.Reason)
+		case s.UpToDate:
+			e.Logger.Outf(logger.Green, "    unchanged\n")
+		default:
+			e.Logger.Outf(logger.Yellow, "    changed\n")
+		}
+		for _, g := range s.MissingGenerates {
+			e.Logger.Outf(logger.Yellow, "    missing generates: %s\n", g)
+		}
+	}
+
+	if len(explanation.Status) > 0 {
+		e.Logger.Outf(logger.Default, "  status:\n")
+		for _, s := range explanation.Status {
+			if s.Err == nil {
+				e.Logger.Outf(logger.Green, "    passed: %s\n", s.Cmd)
+				continue
+			}
+			e.Logger.Outf(logger.Yellow, "    failed: %s (%v)\n", s.Cmd, s.Err)
+			for line := range strings.Lines(s.Output) {
+				e.Logger.Outf(logger.Default, "      %s\n", strings.TrimSuffix(line, "\n"))
+			}
+		}
+	}
+}